@@ -206,7 +206,10 @@ func (s *AuthService) CompleteOAuthLogin(ctx context.Context, gothUser goth.User
 	if user != nil {
 		user.Provider = gothUser.Provider
 		user.ProviderID = &gothUser.UserID
-		user.ProfileImage = &gothUser.AvatarURL
+		// Avatar enviado pelo usuário tem prioridade sobre o do provedor
+		if user.AvatarKey == nil {
+			user.ProfileImage = &gothUser.AvatarURL
+		}
 
 		if gothUser.Provider == "github" {
              // O token vem aqui. Recomendo criptografar antes de salvar (ver nota abaixo)
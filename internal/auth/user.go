@@ -19,6 +19,9 @@ type User struct {
 	CreatedAt    time.Time
 	ProfileImage *string
 	GithubAcessToken *string
+	// Versão do avatar enviado pelo usuário (ex: "3f2a9c1e.jpg"); nil quando a
+	// imagem vem do provedor OAuth
+	AvatarKey *string
 }
 
 func hashPassword(password string) (string, error) {
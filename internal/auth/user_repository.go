@@ -24,8 +24,8 @@ type userRepo struct {
 func (u *userRepo) Create(ctx context.Context, user *User) error {
 	user.Email = strings.ToLower(user.Email)
 	query := `
-		INSERT INTO users (id, first_name, last_name, email, password_hash, provider, provider_id, reset_token, created_at, profile_image, github_access_token)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		INSERT INTO users (id, first_name, last_name, email, password_hash, provider, provider_id, reset_token, created_at, profile_image, github_access_token, avatar_key)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 	`
 	_, err := u.db.ExecContext(ctx, query,
 		user.ID,
@@ -39,6 +39,7 @@ func (u *userRepo) Create(ctx context.Context, user *User) error {
 		user.CreatedAt,
 		user.ProfileImage,
 		user.GithubAcessToken,
+		user.AvatarKey,
 	)
 	return err
 }
@@ -47,7 +48,7 @@ func (u *userRepo) Create(ctx context.Context, user *User) error {
 func (u *userRepo) FindByEmail(ctx context.Context, email string) (*User, error) {
 	email = strings.ToLower(email)
 	query := `
-		SELECT id, first_name, last_name, email, password_hash, provider, provider_id, reset_token, created_at, profile_image, github_access_token
+		SELECT id, first_name, last_name, email, password_hash, provider, provider_id, reset_token, created_at, profile_image, github_access_token, avatar_key
 		FROM users
 		WHERE email = $1
 		LIMIT 1
@@ -65,6 +66,7 @@ func (u *userRepo) FindByEmail(ctx context.Context, email string) (*User, error)
 		&user.CreatedAt,
 		&user.ProfileImage,
 		&user.GithubAcessToken,
+		&user.AvatarKey,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -78,7 +80,7 @@ func (u *userRepo) FindByEmail(ctx context.Context, email string) (*User, error)
 func (u *userRepo) Find(ctx context.Context, id string) (*User, error) {
 	
 	query := `
-		SELECT id, first_name, last_name, email, password_hash, provider, provider_id, reset_token, created_at, profile_image, github_access_token
+		SELECT id, first_name, last_name, email, password_hash, provider, provider_id, reset_token, created_at, profile_image, github_access_token, avatar_key
 		FROM users
 		WHERE id = $1
 		LIMIT 1
@@ -96,6 +98,7 @@ func (u *userRepo) Find(ctx context.Context, id string) (*User, error) {
 		&user.CreatedAt,
 		&user.ProfileImage,
 		&user.GithubAcessToken,
+		&user.AvatarKey,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -112,8 +115,9 @@ func (u *userRepo) Save(ctx context.Context, user *User) error {
 	query := `
 		UPDATE users
 		SET first_name = $1, last_name = $2, email = $3, password_hash = $4, 
-		    provider = $5, provider_id = $6, reset_token = $7, profile_image = $8, github_access_token = $9
-		WHERE id = $10
+		    provider = $5, provider_id = $6, reset_token = $7, profile_image = $8, github_access_token = $9,
+		    avatar_key = $10
+		WHERE id = $11
 	`
 	result, err := u.db.ExecContext(ctx, query,
 		user.FirstName,
@@ -125,6 +129,7 @@ func (u *userRepo) Save(ctx context.Context, user *User) error {
 		user.ResetToken,
 		user.ProfileImage,
 		user.GithubAcessToken,
+		user.AvatarKey,
 		user.ID,
 	)
 	if err != nil {
@@ -142,7 +147,7 @@ func (u *userRepo) Save(ctx context.Context, user *User) error {
 
 func (u *userRepo) FindByResetToken(ctx context.Context, token string) (*User, error) {
 	query := `
-		SELECT id, first_name, last_name, email, password_hash, provider, provider_id, reset_token, created_at, profile_image, github_access_token
+		SELECT id, first_name, last_name, email, password_hash, provider, provider_id, reset_token, created_at, profile_image, github_access_token, avatar_key
 		FROM users
 		WHERE reset_token = $1
 		LIMIT 1
@@ -160,6 +165,7 @@ func (u *userRepo) FindByResetToken(ctx context.Context, token string) (*User, e
 		&user.CreatedAt,
 		&user.ProfileImage,
 		&user.GithubAcessToken,
+		&user.AvatarKey,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -173,7 +179,7 @@ func (u *userRepo) FindByResetToken(ctx context.Context, token string) (*User, e
 // FindByProviderID implements [UserRepository].
 func (u *userRepo) FindByProviderID(ctx context.Context, provider, providerID string) (*User, error) {
 	query := `
-		SELECT id, first_name, last_name, email, password_hash, provider, provider_id, reset_token, created_at, profile_image, github_access_token
+		SELECT id, first_name, last_name, email, password_hash, provider, provider_id, reset_token, created_at, profile_image, github_access_token, avatar_key
 		FROM users
 		WHERE provider = $1 AND provider_id = $2
 		LIMIT 1
@@ -191,6 +197,7 @@ func (u *userRepo) FindByProviderID(ctx context.Context, provider, providerID st
 		&user.CreatedAt,
 		&user.ProfileImage,
 		&user.GithubAcessToken,
+		&user.AvatarKey,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
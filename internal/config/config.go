@@ -3,8 +3,10 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -42,6 +44,28 @@ type Config struct {
 	MeiliHost      string
 	MeiliMasterKey string
 	AppRedirectURL string
+
+	// Anos mínimos de experiência usados na sugestão de senioridade
+	SeniorityMidLevelYears  int
+	SenioritySeniorYears    int
+	SeniorityLeadYears      int
+	SeniorityPrincipalYears int
+
+	// Armazenamento de arquivos enviados (local, s3 ou memory)
+	StorageDriver     string
+	StorageLocalPath  string
+	S3Endpoint        string
+	S3Region          string
+	S3Bucket          string
+	S3AccessKeyID     string
+	S3SecretAccessKey string
+	S3UseSSL          bool
+
+	// E-mails com acesso à API administrativa (ex: taxonomia de habilidades)
+	AdminEmails []string
+
+	// Proxies reversos (IPs ou CIDRs) cujo X-Forwarded-For é confiável
+	TrustedProxies []string
 }
 
 func LoadConfig() (*Config, error) {
@@ -70,6 +94,24 @@ func LoadConfig() (*Config, error) {
 		MeiliHost:      getEnv("MEILI_HOST", "http://localhost:7700"),
 		MeiliMasterKey: getEnv("MEILI_MASTER_KEY", ""),
 		AppRedirectURL: getEnv("APP_REDIRECT_URL", "http://localhost:8080"),
+		// Sugestão de senioridade
+		SeniorityMidLevelYears:  getEnvAsInt("SENIORITY_MID_LEVEL_YEARS", 2),
+		SenioritySeniorYears:    getEnvAsInt("SENIORITY_SENIOR_YEARS", 5),
+		SeniorityLeadYears:      getEnvAsInt("SENIORITY_LEAD_YEARS", 8),
+		SeniorityPrincipalYears: getEnvAsInt("SENIORITY_PRINCIPAL_YEARS", 12),
+		// Armazenamento de arquivos
+		StorageDriver:     getEnv("STORAGE_DRIVER", "local"),
+		StorageLocalPath:  getEnv("STORAGE_LOCAL_PATH", "./data/blobs"),
+		S3Endpoint:        getEnv("S3_ENDPOINT", ""),
+		S3Region:          getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:          getEnv("S3_BUCKET", ""),
+		S3AccessKeyID:     getEnv("S3_ACCESS_KEY_ID", ""),
+		S3SecretAccessKey: getEnv("S3_SECRET_ACCESS_KEY", ""),
+		S3UseSSL:          getEnvAsBool("S3_USE_SSL", true),
+		// Administração
+		AdminEmails: getEnvAsList("ADMIN_EMAILS"),
+		// Rede
+		TrustedProxies: getEnvAsList("TRUSTED_PROXIES"),
 	}
 
 	if err := cfg.validate(); err != nil {
@@ -118,6 +160,31 @@ func (c *Config) validate() error {
 		errs = append(errs, errors.New("MEILI_MASTER_KEY is required"))
 	}
 
+	if !(c.SeniorityMidLevelYears < c.SenioritySeniorYears &&
+		c.SenioritySeniorYears < c.SeniorityLeadYears &&
+		c.SeniorityLeadYears < c.SeniorityPrincipalYears) {
+		errs = append(errs, errors.New("SENIORITY_*_YEARS thresholds must be strictly increasing"))
+	}
+
+	switch c.StorageDriver {
+	case "local", "memory":
+	case "s3":
+		if c.S3Endpoint == "" || c.S3Bucket == "" {
+			errs = append(errs, errors.New("S3_ENDPOINT and S3_BUCKET are required when STORAGE_DRIVER=s3"))
+		}
+		if c.S3AccessKeyID == "" || c.S3SecretAccessKey == "" {
+			errs = append(errs, errors.New("S3_ACCESS_KEY_ID and S3_SECRET_ACCESS_KEY are required when STORAGE_DRIVER=s3"))
+		}
+	default:
+		errs = append(errs, errors.New("STORAGE_DRIVER must be local, s3 or memory"))
+	}
+
+	for _, proxy := range c.TrustedProxies {
+		if parseProxyNet(proxy) == nil {
+			errs = append(errs, fmt.Errorf("TRUSTED_PROXIES: invalid IP or CIDR %q", proxy))
+		}
+	}
+
 	// Validações de OAuth (obrigatórias em produção)
 	if c.IsProduction {
 		if c.GoogleClientID == "" {
@@ -163,6 +230,17 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// getEnvAsList retorna os valores separados por vírgula da variável de ambiente
+func getEnvAsList(key string) []string {
+	values := []string{}
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
 // getEnvAsBool retorna o valor da variável de ambiente como bool ou um valor padrão
 func getEnvAsBool(key string, defaultValue bool) bool {
 	if value, exists := os.LookupEnv(key); exists {
@@ -171,4 +249,40 @@ func getEnvAsBool(key string, defaultValue bool) bool {
 		}
 	}
 	return defaultValue
-}
\ No newline at end of file
+}
+
+// IsAdmin indica se o e-mail tem acesso à API administrativa
+func (c *Config) IsAdmin(email string) bool {
+	for _, admin := range c.AdminEmails {
+		if strings.EqualFold(admin, email) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTrustedProxy indica se o IP é de um proxy configurado em TRUSTED_PROXIES
+func (c *Config) IsTrustedProxy(ip net.IP) bool {
+	for _, proxy := range c.TrustedProxies {
+		if network := parseProxyNet(proxy); network != nil && network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseProxyNet aceita um CIDR ou um IP isolado (tratado como /32 ou /128)
+func parseProxyNet(value string) *net.IPNet {
+	if _, network, err := net.ParseCIDR(value); err == nil {
+		return network
+	}
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return nil
+	}
+	bits := 128
+	if ip.To4() != nil {
+		ip, bits = ip.To4(), 32
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}
+}
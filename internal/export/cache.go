@@ -0,0 +1,70 @@
+package export
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultCacheEntries é o número de documentos mantidos em memória
+const DefaultCacheEntries = 128
+
+// Cache guarda documentos já renderizados. A chave inclui o UpdatedAt do
+// perfil, então qualquer alteração gera uma chave nova e a entrada antiga
+// simplesmente deixa de ser usada até ser descartada (FIFO).
+type Cache struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[string][]byte
+	order      []string
+}
+
+func NewCache(maxEntries int) *Cache {
+	if maxEntries <= 0 {
+		maxEntries = DefaultCacheEntries
+	}
+	return &Cache{
+		maxEntries: maxEntries,
+		entries:    make(map[string][]byte),
+	}
+}
+
+func (c *Cache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	data, ok := c.entries[key]
+	return data, ok
+}
+
+func (c *Cache) Put(key string, data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[key]; ok {
+		c.entries[key] = data
+		return
+	}
+	for len(c.order) >= c.maxEntries {
+		oldest := c.order[0]
+		c.order = c.order[1:]
+		delete(c.entries, oldest)
+	}
+	c.entries[key] = data
+	c.order = append(c.order, key)
+}
+
+// CacheKey identifica um documento renderizado: mesmo perfil, mesma versão,
+// mesmo idioma e mesmos campos ocultos para o visualizador geram o mesmo arquivo.
+// O resultado também serve de ETag.
+func CacheKey(format string, doc Document) string {
+	p := doc.Profile
+	redacted := append([]string{}, p.RedactedFields...)
+	sort.Strings(redacted)
+
+	raw := fmt.Sprintf("%s|%s|%d|%s|%s|%s", format, p.ID, p.UpdatedAt.UnixNano(), p.Locale,
+		strings.Join(redacted, ","), doc.OwnerName)
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:16])
+}
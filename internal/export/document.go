@@ -0,0 +1,197 @@
+package export
+
+import (
+	"fmt"
+	"net/url"
+	"portfolio/internal/portfolio"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Document é o conteúdo de um currículo exportado: o perfil já traduzido e com
+// os campos restritos removidos para o visualizador, mais os dados do dono
+// (que não fazem parte do Profile).
+type Document struct {
+	OwnerName string
+	Profile   *portfolio.Profile
+}
+
+// SalaryVisible indica se a expectativa salarial deve aparecer no documento
+func (d Document) SalaryVisible() bool {
+	return !d.Profile.IsRedacted(portfolio.FieldGroupSalary) && d.Profile.SalaryExpectation > 0
+}
+
+// ContractVisible indica se modalidade e tipo de contrato devem aparecer no documento
+func (d Document) ContractVisible() bool {
+	return !d.Profile.IsRedacted(portfolio.FieldGroupContract)
+}
+
+// Title é o título do documento: o nome do dono ou, na falta dele, o headline
+func (d Document) Title() string {
+	if d.OwnerName != "" {
+		return d.OwnerName
+	}
+	return d.Profile.Headline
+}
+
+// summaryItems monta a linha de resumo exibida abaixo do headline
+// (senioridade • experiência • modalidade • contrato • salário)
+func (d Document) summaryItems() []string {
+	p := d.Profile
+	l := d.labels()
+	items := []string{}
+	if p.Seniority != "" {
+		items = append(items, l.seniorityLabel(p.Seniority))
+	}
+	items = append(items, fmt.Sprintf(l.yearsOfExp, p.YearsOfExp))
+	if d.ContractVisible() {
+		if p.Location != "" {
+			items = append(items, l.locationLabel(p.Location))
+		}
+		if p.ContractType != "" {
+			items = append(items, p.ContractType)
+		}
+	}
+	if d.SalaryVisible() {
+		items = append(items, fmt.Sprintf("%s %.2f", p.Currency, p.SalaryExpectation))
+	}
+	return items
+}
+
+// formatPeriod formata o intervalo de uma experiência ou formação ("Jan 2020 - Presente")
+func (l labels) formatPeriod(start time.Time, end *time.Time) string {
+	finish := l.present
+	if end != nil {
+		finish = l.month(*end)
+	}
+	return l.month(start) + " - " + finish
+}
+
+// educationTitle monta "Grau em Área", omitindo partes vazias
+func (l labels) educationTitle(edu portfolio.Education) string {
+	switch {
+	case edu.Degree != "" && edu.Field != "":
+		return edu.Degree + l.degreeIn + edu.Field
+	case edu.Degree != "":
+		return edu.Degree
+	case edu.Field != "":
+		return edu.Field
+	default:
+		return edu.Institution
+	}
+}
+
+func (l labels) certificationSubtitle(cert portfolio.Certification) string {
+	credential := ""
+	if cert.CredentialID != "" {
+		credential = l.credential + cert.CredentialID
+	}
+	return joinNonEmpty(" • ", cert.Issuer, credential)
+}
+
+// certificationPeriod formata a emissão e, quando houver, a validade ("Mar 2023 - Mar 2026")
+func (l labels) certificationPeriod(cert portfolio.Certification) string {
+	if cert.ExpiryDate == nil {
+		return l.month(cert.IssueDate)
+	}
+	return l.month(cert.IssueDate) + " - " + l.month(*cert.ExpiryDate)
+}
+
+// skillItems monta a lista de habilidades com nível e tempo ("Go (Avançado, 5 anos)")
+func (l labels) skillItems(skills portfolio.Skills) []string {
+	items := make([]string, len(skills))
+	for i, skill := range skills {
+		items[i] = l.skillLabel(skill)
+	}
+	return items
+}
+
+// languageItems monta a lista de idiomas ("Inglês: Avançado (C1)")
+func (l labels) languageItems(languages portfolio.Languages) []string {
+	items := make([]string, len(languages))
+	for i, lang := range languages {
+		items[i] = l.languageLabel(lang) + ": " + l.languageLevelLabel(lang.Level)
+	}
+	return items
+}
+
+func (l labels) publicationSubtitle(pub portfolio.Publication) string {
+	return joinNonEmpty(" • ", l.publicationKindLabel(pub.Kind), pub.Publisher)
+}
+
+// footer é o rodapé de todos os formatos. Usa a data da última alteração do
+// perfil (e não a data atual) para que o mesmo perfil gere sempre o mesmo arquivo.
+func (d Document) footer() string {
+	l := d.labels()
+	return fmt.Sprintf(l.updatedOn, d.Profile.UpdatedAt.Format(l.dateLayout)) + " • DevPortfolio"
+}
+
+// Link é um endereço externo exibido no documento
+type Link struct {
+	Label string
+	URL   string
+}
+
+// links retorna as redes sociais do perfil, descartando endereços inválidos
+func (d Document) links() []Link {
+	social := d.Profile.SocialLinks
+	return safeLinks(
+		Link{"LinkedIn", social.LinkedIn},
+		Link{"GitHub", social.GitHub},
+		Link{"Website", social.Website},
+	)
+}
+
+func (l labels) projectLinks(proj portfolio.Project) []Link {
+	return safeLinks(Link{l.repository, proj.RepoURL}, Link{l.demo, proj.LiveURL})
+}
+
+func (l labels) certificationLinks(cert portfolio.Certification) []Link {
+	return safeLinks(Link{l.verifyCredential, cert.VerificationURL})
+}
+
+func (l labels) publicationLinks(pub portfolio.Publication) []Link {
+	return safeLinks(Link{l.access, pub.URL})
+}
+
+// safeLinks mantém apenas URLs http(s), evitando que esquemas como
+// javascript: virem links clicáveis nos documentos gerados
+func safeLinks(links ...Link) []Link {
+	result := make([]Link, 0, len(links))
+	for _, link := range links {
+		u, err := url.Parse(strings.TrimSpace(link.URL))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			continue
+		}
+		link.URL = u.String()
+		result = append(result, link)
+	}
+	return result
+}
+
+// cleanText normaliza quebras de linha e remove caracteres de controle
+// (sequências ANSI, NUL etc.) do texto do usuário
+func cleanText(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\r", "\n")
+	return strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\t' {
+			return r
+		}
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+func joinNonEmpty(sep string, values ...string) string {
+	parts := make([]string, 0, len(values))
+	for _, v := range values {
+		if v != "" {
+			parts = append(parts, v)
+		}
+	}
+	return strings.Join(parts, sep)
+}
@@ -0,0 +1,138 @@
+package export
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported export format")
+
+// Renderer converte um Document em um formato de arquivo (PDF, Markdown, ...).
+// Cada implementação é responsável pelo escape do texto do usuário no seu formato.
+type Renderer interface {
+	// Name é o identificador usado em ?format= e na chave de cache
+	Name() string
+	// MediaType é o tipo usado no Content-Type e na negociação pelo Accept
+	MediaType() string
+	Extension() string
+	Render(w io.Writer, doc Document) error
+}
+
+type registry struct {
+	mu        sync.RWMutex
+	byName    map[string]Renderer
+	byMedia   map[string]Renderer
+	renderers []Renderer
+}
+
+var renderers = &registry{
+	byName:  make(map[string]Renderer),
+	byMedia: make(map[string]Renderer),
+}
+
+func init() {
+	Register(pdfFormat{})
+	Register(markdownFormat{}, "md")
+	Register(latexFormat{}, "tex", "moderncv")
+	Register(textFormat{}, "txt", "plain")
+	// Variações de media type enviadas por alguns clientes
+	renderers.byMedia["text/x-markdown"] = markdownFormat{}
+	renderers.byMedia["application/x-tex"] = latexFormat{}
+	renderers.byMedia["text/x-tex"] = latexFormat{}
+}
+
+// Register adiciona um formato de exportação. Os aliases também são aceitos em ?format=.
+func Register(r Renderer, aliases ...string) {
+	renderers.mu.Lock()
+	defer renderers.mu.Unlock()
+	renderers.byName[r.Name()] = r
+	for _, alias := range aliases {
+		renderers.byName[alias] = r
+	}
+	renderers.byMedia[r.MediaType()] = r
+	renderers.renderers = append(renderers.renderers, r)
+}
+
+// Lookup encontra o formato pelo nome ou alias (ex: "markdown", "md")
+func Lookup(format string) (Renderer, bool) {
+	renderers.mu.RLock()
+	defer renderers.mu.RUnlock()
+	r, ok := renderers.byName[strings.ToLower(strings.TrimSpace(format))]
+	return r, ok
+}
+
+// Formats lista os nomes dos formatos registrados
+func Formats() []string {
+	renderers.mu.RLock()
+	defer renderers.mu.RUnlock()
+	names := make([]string, len(renderers.renderers))
+	for i, r := range renderers.renderers {
+		names[i] = r.Name()
+	}
+	return names
+}
+
+// Negotiate escolhe o formato pelo cabeçalho Accept. Retorna ok=false quando o
+// cliente prefere HTML (ou aceita qualquer coisa), mantendo a página como padrão.
+func Negotiate(accept string) (Renderer, bool) {
+	renderers.mu.RLock()
+	defer renderers.mu.RUnlock()
+	for _, mediaType := range parseAccept(accept) {
+		switch mediaType {
+		case "text/html", "application/xhtml+xml", "*/*", "text/*":
+			return nil, false
+		}
+		if r, ok := renderers.byMedia[mediaType]; ok {
+			return r, true
+		}
+	}
+	return nil, false
+}
+
+// parseAccept retorna os media types do cabeçalho ordenados por peso (q), descartando q=0
+func parseAccept(header string) []string {
+	type weighted struct {
+		mediaType string
+		q         float64
+	}
+	var types []weighted
+	for _, part := range strings.Split(header, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+		types = append(types, weighted{mediaType: mediaType, q: q})
+	}
+	sort.SliceStable(types, func(i, j int) bool { return types[i].q > types[j].q })
+
+	result := make([]string, len(types))
+	for i, t := range types {
+		result[i] = t.mediaType
+	}
+	return result
+}
+
+// ContentType é o valor do cabeçalho Content-Type; formatos textuais são sempre UTF-8
+func ContentType(r Renderer) string {
+	mediaType := r.MediaType()
+	if strings.HasPrefix(mediaType, "text/") || mediaType == "application/x-latex" {
+		return mediaType + "; charset=utf-8"
+	}
+	return mediaType
+}
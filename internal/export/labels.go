@@ -0,0 +1,294 @@
+package export
+
+import (
+	"fmt"
+	"portfolio/internal/portfolio"
+	"strings"
+	"time"
+)
+
+// labels são os textos fixos de um documento exportado em um idioma. Os mapas
+// vazios usam os nomes do próprio domínio (Seniority.Label() etc.), que já estão
+// em português.
+type labels struct {
+	openToWork string
+	// yearsOfExp recebe os anos de experiência ("%d anos de experiência")
+	yearsOfExp string
+	present    string
+	// degreeIn junta grau e área ("Bacharelado em Computação")
+	degreeIn   string
+	credential string
+	// updatedOn recebe a data da última alteração, no formato dateLayout
+	updatedOn  string
+	dateLayout string
+	months     [12]string
+	oneYear    string
+	years      string
+
+	summary        string
+	skills         string
+	experience     string
+	projects       string
+	education      string
+	certifications string
+	languages      string
+	publications   string
+
+	techStack        string
+	tags             string
+	repository       string
+	demo             string
+	verifyCredential string
+	access           string
+
+	seniority       map[portfolio.Seniority]string
+	location        map[portfolio.LocationType]string
+	skillLevel      map[portfolio.SkillLevel]string
+	languageLevel   map[portfolio.LanguageLevel]string
+	publicationKind map[portfolio.PublicationKind]string
+	// languageNames é indexado pelo código ISO 639-1
+	languageNames map[string]string
+}
+
+var exportLabels = map[portfolio.Locale]labels{
+	portfolio.LocalePtBR: {
+		openToWork: "Aberto a propostas",
+		yearsOfExp: "%d anos de experiência",
+		present:    "Presente",
+		degreeIn:   " em ",
+		credential: "Credencial ",
+		updatedOn:  "Atualizado em %s",
+		dateLayout: "02/01/2006",
+		months:     [12]string{"Jan", "Fev", "Mar", "Abr", "Mai", "Jun", "Jul", "Ago", "Set", "Out", "Nov", "Dez"},
+		oneYear:    "1 ano",
+		years:      "%d anos",
+
+		summary:        "Resumo",
+		skills:         "Habilidades",
+		experience:     "Experiência Profissional",
+		projects:       "Projetos",
+		education:      "Formação",
+		certifications: "Certificações",
+		languages:      "Idiomas",
+		publications:   "Publicações e Palestras",
+
+		techStack:        "Tecnologias",
+		tags:             "Tags",
+		repository:       "Repositório",
+		demo:             "Demo",
+		verifyCredential: "Verificar credencial",
+		access:           "Acessar",
+	},
+	portfolio.LocaleEn: {
+		openToWork: "Open to work",
+		yearsOfExp: "%d years of experience",
+		present:    "Present",
+		degreeIn:   " in ",
+		credential: "Credential ",
+		updatedOn:  "Updated on %s",
+		dateLayout: "2006-01-02",
+		months:     [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		oneYear:    "1 year",
+		years:      "%d years",
+
+		summary:        "Summary",
+		skills:         "Skills",
+		experience:     "Professional Experience",
+		projects:       "Projects",
+		education:      "Education",
+		certifications: "Certifications",
+		languages:      "Languages",
+		publications:   "Publications and Talks",
+
+		techStack:        "Technologies",
+		tags:             "Tags",
+		repository:       "Repository",
+		demo:             "Demo",
+		verifyCredential: "Verify credential",
+		access:           "Open",
+
+		seniority: map[portfolio.Seniority]string{
+			portfolio.Junior:    "Junior",
+			portfolio.MidLevel:  "Mid-level",
+			portfolio.Senior:    "Senior",
+			portfolio.Lead:      "Tech Lead",
+			portfolio.Principal: "Principal",
+		},
+		location: map[portfolio.LocationType]string{
+			portfolio.LocationOnSite: "On-site",
+			portfolio.LocationRemote: "Remote",
+			portfolio.LocationHybrid: "Hybrid",
+			portfolio.LocationAny:    "Any",
+		},
+		skillLevel: map[portfolio.SkillLevel]string{
+			portfolio.SkillBeginner:     "Beginner",
+			portfolio.SkillIntermediate: "Intermediate",
+			portfolio.SkillAdvanced:     "Advanced",
+			portfolio.SkillExpert:       "Expert",
+		},
+		languageLevel: map[portfolio.LanguageLevel]string{
+			portfolio.LevelA1:     "Basic (A1)",
+			portfolio.LevelA2:     "Basic (A2)",
+			portfolio.LevelB1:     "Intermediate (B1)",
+			portfolio.LevelB2:     "Intermediate (B2)",
+			portfolio.LevelC1:     "Advanced (C1)",
+			portfolio.LevelC2:     "Proficient (C2)",
+			portfolio.LevelNative: "Native",
+		},
+		publicationKind: map[portfolio.PublicationKind]string{
+			portfolio.PublicationArticle: "Article",
+			portfolio.PublicationTalk:    "Talk",
+			portfolio.PublicationPaper:   "Paper",
+			portfolio.PublicationBook:    "Book",
+			portfolio.PublicationPodcast: "Podcast",
+		},
+		languageNames: map[string]string{
+			"pt": "Portuguese", "en": "English", "es": "Spanish", "fr": "French",
+			"de": "German", "it": "Italian", "nl": "Dutch", "sv": "Swedish",
+			"pl": "Polish", "ru": "Russian", "uk": "Ukrainian", "tr": "Turkish",
+			"ar": "Arabic", "he": "Hebrew", "hi": "Hindi", "zh": "Chinese",
+			"ja": "Japanese", "ko": "Korean", "eo": "Esperanto",
+		},
+	},
+	portfolio.LocaleEs: {
+		openToWork: "Abierto a propuestas",
+		yearsOfExp: "%d años de experiencia",
+		present:    "Actualidad",
+		degreeIn:   " en ",
+		credential: "Credencial ",
+		updatedOn:  "Actualizado el %s",
+		dateLayout: "02/01/2006",
+		months:     [12]string{"Ene", "Feb", "Mar", "Abr", "May", "Jun", "Jul", "Ago", "Sep", "Oct", "Nov", "Dic"},
+		oneYear:    "1 año",
+		years:      "%d años",
+
+		summary:        "Resumen",
+		skills:         "Habilidades",
+		experience:     "Experiencia Profesional",
+		projects:       "Proyectos",
+		education:      "Formación",
+		certifications: "Certificaciones",
+		languages:      "Idiomas",
+		publications:   "Publicaciones y Charlas",
+
+		techStack:        "Tecnologías",
+		tags:             "Etiquetas",
+		repository:       "Repositorio",
+		demo:             "Demo",
+		verifyCredential: "Verificar credencial",
+		access:           "Acceder",
+
+		seniority: map[portfolio.Seniority]string{
+			portfolio.Junior:    "Junior",
+			portfolio.MidLevel:  "Semi senior",
+			portfolio.Senior:    "Senior",
+			portfolio.Lead:      "Tech Lead",
+			portfolio.Principal: "Principal",
+		},
+		location: map[portfolio.LocationType]string{
+			portfolio.LocationOnSite: "Presencial",
+			portfolio.LocationRemote: "Remoto",
+			portfolio.LocationHybrid: "Híbrido",
+			portfolio.LocationAny:    "Cualquiera",
+		},
+		skillLevel: map[portfolio.SkillLevel]string{
+			portfolio.SkillBeginner:     "Principiante",
+			portfolio.SkillIntermediate: "Intermedio",
+			portfolio.SkillAdvanced:     "Avanzado",
+			portfolio.SkillExpert:       "Experto",
+		},
+		languageLevel: map[portfolio.LanguageLevel]string{
+			portfolio.LevelA1:     "Básico (A1)",
+			portfolio.LevelA2:     "Básico (A2)",
+			portfolio.LevelB1:     "Intermedio (B1)",
+			portfolio.LevelB2:     "Intermedio (B2)",
+			portfolio.LevelC1:     "Avanzado (C1)",
+			portfolio.LevelC2:     "Maestría (C2)",
+			portfolio.LevelNative: "Nativo",
+		},
+		publicationKind: map[portfolio.PublicationKind]string{
+			portfolio.PublicationArticle: "Artículo",
+			portfolio.PublicationTalk:    "Charla",
+			portfolio.PublicationPaper:   "Artículo científico",
+			portfolio.PublicationBook:    "Libro",
+			portfolio.PublicationPodcast: "Podcast",
+		},
+		languageNames: map[string]string{
+			"pt": "Portugués", "en": "Inglés", "es": "Español", "fr": "Francés",
+			"de": "Alemán", "it": "Italiano", "nl": "Neerlandés", "sv": "Sueco",
+			"pl": "Polaco", "ru": "Ruso", "uk": "Ucraniano", "tr": "Turco",
+			"ar": "Árabe", "he": "Hebreo", "hi": "Hindi", "zh": "Chino",
+			"ja": "Japonés", "ko": "Coreano", "eo": "Esperanto",
+		},
+	},
+}
+
+// labels retorna os textos no idioma do perfil exportado (o mesmo usado no babel do LaTeX)
+func (d Document) labels() labels {
+	if l, ok := exportLabels[d.Profile.Locale]; ok {
+		return l
+	}
+	return exportLabels[portfolio.DefaultLocale]
+}
+
+// month formata mês e ano ("Jan 2020") com o nome do mês no idioma do documento
+func (l labels) month(t time.Time) string {
+	return l.months[t.Month()-1] + " " + t.Format("2006")
+}
+
+func (l labels) seniorityLabel(s portfolio.Seniority) string {
+	if label, ok := l.seniority[s]; ok {
+		return label
+	}
+	return s.Label()
+}
+
+func (l labels) locationLabel(loc portfolio.LocationType) string {
+	if label, ok := l.location[loc]; ok {
+		return label
+	}
+	return loc.Label()
+}
+
+func (l labels) languageLevelLabel(level portfolio.LanguageLevel) string {
+	if label, ok := l.languageLevel[level]; ok {
+		return label
+	}
+	return level.Label()
+}
+
+func (l labels) languageLabel(lang portfolio.LanguageSkill) string {
+	if label, ok := l.languageNames[lang.Language]; ok {
+		return label
+	}
+	return lang.LanguageLabel()
+}
+
+func (l labels) publicationKindLabel(kind portfolio.PublicationKind) string {
+	if label, ok := l.publicationKind[kind]; ok {
+		return label
+	}
+	return kind.Label()
+}
+
+// skillLabel monta "Go (Avançado, 5 anos)" no idioma do documento
+func (l labels) skillLabel(skill portfolio.Skill) string {
+	var parts []string
+	if skill.Level.IsValid() {
+		if label, ok := l.skillLevel[skill.Level]; ok {
+			parts = append(parts, label)
+		} else {
+			parts = append(parts, skill.Level.Label())
+		}
+	}
+	switch {
+	case skill.Years == 1:
+		parts = append(parts, l.oneYear)
+	case skill.Years > 1:
+		parts = append(parts, fmt.Sprintf(l.years, skill.Years))
+	}
+	if len(parts) == 0 {
+		return skill.Name
+	}
+	return skill.Name + " (" + strings.Join(parts, ", ") + ")"
+}
@@ -0,0 +1,186 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"portfolio/internal/portfolio"
+	"strings"
+)
+
+type latexFormat struct{}
+
+func (latexFormat) Name() string      { return "latex" }
+func (latexFormat) MediaType() string { return "application/x-latex" }
+func (latexFormat) Extension() string { return "tex" }
+
+// latexEscaper escapa os caracteres especiais do LaTeX em uma única passada
+// (a barra invertida não é escapada de novo pelas substituições seguintes)
+var latexEscaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`, `{`, `\{`, `}`, `\}`, `$`, `\$`, `&`, `\&`,
+	`#`, `\#`, `_`, `\_`, `%`, `\%`, `^`, `\textasciicircum{}`,
+	`~`, `\textasciitilde{}`, `<`, `\textless{}`, `>`, `\textgreater{}`,
+	`•`, `\textbullet{}`,
+)
+
+func escapeLaTeX(s string) string {
+	lines := strings.Split(cleanText(s), "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if line = strings.TrimSpace(line); line != "" {
+			kept = append(kept, latexEscaper.Replace(line))
+		}
+	}
+	return strings.Join(kept, `\newline{}`)
+}
+
+// latexURL escapa o que o hyperref não aceita literalmente dentro de \href
+var latexURL = strings.NewReplacer(`\`, "%5C", `{`, "%7B", `}`, "%7D", `%`, `\%`, `#`, `\#`)
+
+// latexBabel mapeia o idioma do conteúdo para a opção do pacote babel
+var latexBabel = map[portfolio.Locale]string{
+	portfolio.LocalePtBR: "brazil",
+	portfolio.LocaleEn:   "english",
+	portfolio.LocaleEs:   "spanish",
+}
+
+// socialHandle extrai o usuário de uma URL de rede social (último segmento do caminho)
+func socialHandle(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	return path.Base(strings.TrimRight(u.Path, "/"))
+}
+
+// Render gera um currículo no estilo moderncv (classic)
+func (latexFormat) Render(w io.Writer, doc Document) error {
+	p := doc.Profile
+	l := doc.labels()
+	out := bufio.NewWriter(w)
+
+	babel, ok := latexBabel[p.Locale]
+	if !ok {
+		babel = latexBabel[portfolio.DefaultLocale]
+	}
+
+	fmt.Fprintf(out, "%% %s\n", doc.footer())
+	fmt.Fprint(out, "\\documentclass[11pt,a4paper,sans]{moderncv}\n")
+	fmt.Fprint(out, "\\moderncvstyle{classic}\n\\moderncvcolor{blue}\n")
+	fmt.Fprint(out, "\\usepackage[utf8]{inputenc}\n\\usepackage[T1]{fontenc}\n")
+	fmt.Fprintf(out, "\\usepackage[%s]{babel}\n", babel)
+	fmt.Fprint(out, "\\usepackage[scale=0.8]{geometry}\n\n")
+
+	first, last, _ := strings.Cut(doc.Title(), " ")
+	fmt.Fprintf(out, "\\name{%s}{%s}\n", escapeLaTeX(first), escapeLaTeX(last))
+	if doc.OwnerName != "" && p.Headline != "" {
+		fmt.Fprintf(out, "\\title{%s}\n", escapeLaTeX(p.Headline))
+	}
+	for _, link := range doc.links() {
+		switch link.Label {
+		case "LinkedIn":
+			fmt.Fprintf(out, "\\social[linkedin]{%s}\n", escapeLaTeX(socialHandle(link.URL)))
+		case "GitHub":
+			fmt.Fprintf(out, "\\social[github]{%s}\n", escapeLaTeX(socialHandle(link.URL)))
+		default:
+			fmt.Fprintf(out, "\\homepage{%s}\n", latexURL.Replace(link.URL))
+		}
+	}
+	if p.OpenToWork {
+		fmt.Fprintf(out, "\\quote{%s}\n", escapeLaTeX(l.openToWork))
+	}
+
+	fmt.Fprint(out, "\n\\begin{document}\n\\makecvtitle\n\n")
+
+	fmt.Fprintf(out, "\\section{%s}\n", l.summary)
+	if p.Bio != "" {
+		fmt.Fprintf(out, "\\cvitem{}{%s}\n", escapeLaTeX(p.Bio))
+	}
+	fmt.Fprintf(out, "\\cvitem{}{%s}\n\n", escapeLaTeX(strings.Join(doc.summaryItems(), " • ")))
+
+	if len(p.Skills) > 0 {
+		fmt.Fprintf(out, "\\section{%s}\n", l.skills)
+		fmt.Fprintf(out, "\\cvitem{}{%s}\n\n", escapeLaTeX(strings.Join(l.skillItems(p.Skills), ", ")))
+	}
+
+	if len(p.Experiences) > 0 {
+		fmt.Fprintf(out, "\\section{%s}\n", l.experience)
+		for _, exp := range p.Experiences {
+			description := escapeLaTeX(exp.Description)
+			if len(exp.TechStack) > 0 {
+				tech := `\textit{` + escapeLaTeX(strings.Join(exp.TechStack, ", ")) + `}`
+				description = joinNonEmpty(`\newline{}`, description, tech)
+			}
+			fmt.Fprintf(out, "\\cventry{%s}{%s}{%s}{}{}{%s}\n",
+				escapeLaTeX(l.formatPeriod(exp.StartDate, exp.EndDate)), escapeLaTeX(exp.Role), escapeLaTeX(exp.Company), description)
+		}
+		fmt.Fprintln(out)
+	}
+
+	if len(p.Projects) > 0 {
+		fmt.Fprintf(out, "\\section{%s}\n", l.projects)
+		for _, proj := range p.Projects {
+			parts := []string{escapeLaTeX(proj.Description)}
+			if len(proj.Tags) > 0 {
+				parts = append(parts, `\textit{`+escapeLaTeX(strings.Join(proj.Tags, ", "))+`}`)
+			}
+			links := l.projectLinks(proj)
+			hrefs := make([]string, len(links))
+			for i, link := range links {
+				hrefs[i] = `\href{` + latexURL.Replace(link.URL) + `}{` + escapeLaTeX(link.Label) + `}`
+			}
+			parts = append(parts, strings.Join(hrefs, " "))
+			fmt.Fprintf(out, "\\cvitem{%s}{%s}\n", escapeLaTeX(proj.Name), joinNonEmpty(`\newline{}`, parts...))
+		}
+		fmt.Fprintln(out)
+	}
+
+	if len(p.Educations) > 0 {
+		fmt.Fprintf(out, "\\section{%s}\n", l.education)
+		for _, edu := range p.Educations {
+			fmt.Fprintf(out, "\\cventry{%s}{%s}{%s}{}{}{}\n",
+				escapeLaTeX(l.formatPeriod(edu.StartDate, edu.EndDate)), escapeLaTeX(l.educationTitle(edu)), escapeLaTeX(edu.Institution))
+		}
+		fmt.Fprintln(out)
+	}
+
+	if len(p.Certifications) > 0 {
+		fmt.Fprintf(out, "\\section{%s}\n", l.certifications)
+		for _, cert := range p.Certifications {
+			links := l.certificationLinks(cert)
+			hrefs := make([]string, len(links))
+			for i, link := range links {
+				hrefs[i] = `\href{` + latexURL.Replace(link.URL) + `}{` + escapeLaTeX(link.Label) + `}`
+			}
+			fmt.Fprintf(out, "\\cventry{%s}{%s}{%s}{}{}{%s}\n",
+				escapeLaTeX(l.certificationPeriod(cert)), escapeLaTeX(cert.Name), escapeLaTeX(l.certificationSubtitle(cert)), strings.Join(hrefs, " "))
+		}
+		fmt.Fprintln(out)
+	}
+
+	if len(p.Languages) > 0 {
+		fmt.Fprintf(out, "\\section{%s}\n", l.languages)
+		for _, lang := range p.Languages {
+			fmt.Fprintf(out, "\\cvitem{%s}{%s}\n", escapeLaTeX(l.languageLabel(lang)), escapeLaTeX(l.languageLevelLabel(lang.Level)))
+		}
+		fmt.Fprintln(out)
+	}
+
+	if len(p.Publications) > 0 {
+		fmt.Fprintf(out, "\\section{%s}\n", l.publications)
+		for _, pub := range p.Publications {
+			parts := []string{escapeLaTeX(pub.Description)}
+			for _, link := range l.publicationLinks(pub) {
+				parts = append(parts, `\href{`+latexURL.Replace(link.URL)+`}{`+escapeLaTeX(link.Label)+`}`)
+			}
+			fmt.Fprintf(out, "\\cventry{%s}{%s}{%s}{}{}{%s}\n",
+				escapeLaTeX(l.month(pub.Date)), escapeLaTeX(pub.Title), escapeLaTeX(l.publicationSubtitle(pub)), joinNonEmpty(`\newline{}`, parts...))
+		}
+		fmt.Fprintln(out)
+	}
+
+	fmt.Fprint(out, "\\end{document}\n")
+	return out.Flush()
+}
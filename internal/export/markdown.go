@@ -0,0 +1,156 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+type markdownFormat struct{}
+
+func (markdownFormat) Name() string      { return "markdown" }
+func (markdownFormat) MediaType() string { return "text/markdown" }
+func (markdownFormat) Extension() string { return "md" }
+
+// markdownEscaper neutraliza a sintaxe inline (ênfase, links, HTML, tabelas)
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`, `[`, `\[`, `]`, `\]`,
+	`<`, `\<`, `>`, `\>`, `#`, `\#`, `|`, `\|`, `~`, `\~`,
+)
+
+// markdownBlockStart detecta início de linha que viraria lista, título ou bloco
+var markdownBlockStart = regexp.MustCompile(`^(\s*)([-+=]|\d+\.)`)
+
+// escapeMarkdown escapa um texto de uma linha
+func escapeMarkdown(s string) string {
+	s = markdownEscaper.Replace(strings.TrimSpace(s))
+	return markdownBlockStart.ReplaceAllStringFunc(s, func(prefix string) string {
+		i := len(prefix) - 1
+		return prefix[:i] + `\` + prefix[i:]
+	})
+}
+
+// markdownParagraphs escapa um texto com várias linhas, mantendo parágrafos
+func markdownParagraphs(s string) string {
+	lines := strings.Split(cleanText(s), "\n")
+	for i, line := range lines {
+		lines[i] = escapeMarkdown(line)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// markdownURL escapa os caracteres que fechariam o destino do link
+var markdownURL = strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29", "<", "%3C", ">", "%3E")
+
+func markdownLink(link Link) string {
+	return "[" + escapeMarkdown(link.Label) + "](" + markdownURL.Replace(link.URL) + ")"
+}
+
+func (markdownFormat) Render(w io.Writer, doc Document) error {
+	p := doc.Profile
+	l := doc.labels()
+	out := bufio.NewWriter(w)
+
+	fmt.Fprintf(out, "# %s\n\n", escapeMarkdown(doc.Title()))
+	if doc.OwnerName != "" && p.Headline != "" {
+		fmt.Fprintf(out, "**%s**\n\n", escapeMarkdown(p.Headline))
+	}
+	if p.OpenToWork {
+		fmt.Fprintf(out, "_%s_\n\n", l.openToWork)
+	}
+	if p.Bio != "" {
+		fmt.Fprintf(out, "%s\n\n", markdownParagraphs(p.Bio))
+	}
+	fmt.Fprintf(out, "%s\n\n", escapeMarkdown(strings.Join(doc.summaryItems(), " • ")))
+	if links := doc.links(); len(links) > 0 {
+		for _, link := range links {
+			fmt.Fprintf(out, "- %s: %s\n", escapeMarkdown(link.Label), markdownLink(Link{link.URL, link.URL}))
+		}
+		fmt.Fprintln(out)
+	}
+
+	if len(p.Skills) > 0 {
+		fmt.Fprintf(out, "## %s\n\n", l.skills)
+		fmt.Fprintf(out, "%s\n\n", escapeMarkdown(strings.Join(l.skillItems(p.Skills), " • ")))
+	}
+
+	if len(p.Experiences) > 0 {
+		fmt.Fprintf(out, "## %s\n\n", l.experience)
+		for _, exp := range p.Experiences {
+			fmt.Fprintf(out, "### %s\n\n", escapeMarkdown(joinNonEmpty(" — ", exp.Role, exp.Company)))
+			fmt.Fprintf(out, "_%s_\n\n", escapeMarkdown(l.formatPeriod(exp.StartDate, exp.EndDate)))
+			if exp.Description != "" {
+				fmt.Fprintf(out, "%s\n\n", markdownParagraphs(exp.Description))
+			}
+			if len(exp.TechStack) > 0 {
+				fmt.Fprintf(out, "**%s:** %s\n\n", l.techStack, escapeMarkdown(strings.Join(exp.TechStack, ", ")))
+			}
+		}
+	}
+
+	if len(p.Projects) > 0 {
+		fmt.Fprintf(out, "## %s\n\n", l.projects)
+		for _, proj := range p.Projects {
+			fmt.Fprintf(out, "### %s\n\n", escapeMarkdown(proj.Name))
+			if proj.Description != "" {
+				fmt.Fprintf(out, "%s\n\n", markdownParagraphs(proj.Description))
+			}
+			if len(proj.Tags) > 0 {
+				fmt.Fprintf(out, "**%s:** %s\n\n", l.tags, escapeMarkdown(strings.Join(proj.Tags, ", ")))
+			}
+			if links := l.projectLinks(proj); len(links) > 0 {
+				rendered := make([]string, len(links))
+				for i, link := range links {
+					rendered[i] = markdownLink(link)
+				}
+				fmt.Fprintf(out, "%s\n\n", strings.Join(rendered, " • "))
+			}
+		}
+	}
+
+	if len(p.Educations) > 0 {
+		fmt.Fprintf(out, "## %s\n\n", l.education)
+		for _, edu := range p.Educations {
+			fmt.Fprintf(out, "### %s\n\n", escapeMarkdown(l.educationTitle(edu)))
+			fmt.Fprintf(out, "%s  \n_%s_\n\n", escapeMarkdown(edu.Institution), escapeMarkdown(l.formatPeriod(edu.StartDate, edu.EndDate)))
+		}
+	}
+
+	if len(p.Certifications) > 0 {
+		fmt.Fprintf(out, "## %s\n\n", l.certifications)
+		for _, cert := range p.Certifications {
+			fmt.Fprintf(out, "### %s\n\n", escapeMarkdown(cert.Name))
+			fmt.Fprintf(out, "%s  \n_%s_\n\n", escapeMarkdown(l.certificationSubtitle(cert)), escapeMarkdown(l.certificationPeriod(cert)))
+			for _, link := range l.certificationLinks(cert) {
+				fmt.Fprintf(out, "%s\n\n", markdownLink(link))
+			}
+		}
+	}
+
+	if len(p.Languages) > 0 {
+		fmt.Fprintf(out, "## %s\n\n", l.languages)
+		for _, item := range l.languageItems(p.Languages) {
+			fmt.Fprintf(out, "- %s\n", escapeMarkdown(item))
+		}
+		fmt.Fprintln(out)
+	}
+
+	if len(p.Publications) > 0 {
+		fmt.Fprintf(out, "## %s\n\n", l.publications)
+		for _, pub := range p.Publications {
+			fmt.Fprintf(out, "### %s\n\n", escapeMarkdown(pub.Title))
+			fmt.Fprintf(out, "%s  \n_%s_\n\n", escapeMarkdown(l.publicationSubtitle(pub)), l.month(pub.Date))
+			if pub.Description != "" {
+				fmt.Fprintf(out, "%s\n\n", markdownParagraphs(pub.Description))
+			}
+			for _, link := range l.publicationLinks(pub) {
+				fmt.Fprintf(out, "%s\n\n", markdownLink(link))
+			}
+		}
+	}
+
+	fmt.Fprintf(out, "---\n\n_%s_\n", escapeMarkdown(doc.footer()))
+	return out.Flush()
+}
@@ -0,0 +1,273 @@
+package export
+
+import (
+	"io"
+	"strings"
+
+	"github.com/go-fonts/liberation/liberationsansbold"
+	"github.com/go-fonts/liberation/liberationsansitalic"
+	"github.com/go-fonts/liberation/liberationsansregular"
+	"github.com/go-pdf/fpdf"
+)
+
+// pdfFont é a família embutida no PDF (cobre acentuação do português)
+const pdfFont = "LiberationSans"
+
+// Cores usadas no layout, as mesmas de print_portfolio.html
+var (
+	colorTitle   = [3]int{31, 41, 55}
+	colorText    = [3]int{75, 85, 99}
+	colorMuted   = [3]int{107, 114, 128}
+	colorLink    = [3]int{37, 99, 235}
+	colorSuccess = [3]int{22, 163, 74}
+	colorBorder  = [3]int{229, 231, 235}
+)
+
+// pdfFormat expõe RenderPDF como Renderer
+type pdfFormat struct{}
+
+func (pdfFormat) Name() string      { return "pdf" }
+func (pdfFormat) MediaType() string { return "application/pdf" }
+func (pdfFormat) Extension() string { return "pdf" }
+func (pdfFormat) Render(w io.Writer, doc Document) error {
+	return RenderPDF(w, doc)
+}
+
+// RenderPDF gera o currículo em PDF (A4) com o mesmo conteúdo da página de
+// impressão: cabeçalho, habilidades, experiências, projetos e formação.
+func RenderPDF(w io.Writer, doc Document) error {
+	p := doc.Profile
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(20, 15, 20)
+	pdf.SetAutoPageBreak(true, 20)
+	pdf.AddUTF8FontFromBytes(pdfFont, "", liberationsansregular.TTF)
+	pdf.AddUTF8FontFromBytes(pdfFont, "B", liberationsansbold.TTF)
+	pdf.AddUTF8FontFromBytes(pdfFont, "I", liberationsansitalic.TTF)
+
+	pdf.SetTitle(doc.Title(), true)
+	pdf.SetAuthor(doc.OwnerName, true)
+	pdf.SetCreator("DevPortfolio", true)
+	// Ordem fixa dos recursos e datas fixas deixam o arquivo determinístico para o cache
+	pdf.SetCatalogSort(true)
+	pdf.SetCreationDate(p.UpdatedAt)
+	pdf.SetModificationDate(p.UpdatedAt)
+
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-15)
+		setFont(pdf, "", 8, colorMuted)
+		pdf.CellFormat(0, 5, doc.footer(), "", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+
+	r := &pdfLayout{pdf: pdf, labels: doc.labels()}
+	r.header(doc)
+	r.skills(r.labels.skillItems(p.Skills))
+	r.experiences(doc)
+	r.projects(doc)
+	r.educations(doc)
+	r.certifications(doc)
+	r.languages(doc)
+	r.publications(doc)
+
+	return pdf.Output(w)
+}
+
+type pdfLayout struct {
+	pdf    *fpdf.Fpdf
+	labels labels
+}
+
+func setFont(pdf *fpdf.Fpdf, style string, size float64, color [3]int) {
+	pdf.SetFont(pdfFont, style, size)
+	pdf.SetTextColor(color[0], color[1], color[2])
+}
+
+// contentWidth é a largura útil da página (sem margens)
+func (r *pdfLayout) contentWidth() float64 {
+	pageW, _ := r.pdf.GetPageSize()
+	left, _, right, _ := r.pdf.GetMargins()
+	return pageW - left - right
+}
+
+func (r *pdfLayout) header(doc Document) {
+	pdf := r.pdf
+	p := doc.Profile
+
+	if doc.OwnerName != "" {
+		setFont(pdf, "B", 20, colorTitle)
+		pdf.CellFormat(0, 9, doc.OwnerName, "", 1, "L", false, 0, "")
+	}
+	if p.Headline != "" {
+		setFont(pdf, "B", 13, colorText)
+		pdf.MultiCell(0, 6, p.Headline, "", "L", false)
+	}
+	if p.OpenToWork {
+		setFont(pdf, "B", 9, colorSuccess)
+		pdf.CellFormat(0, 5, r.labels.openToWork, "", 1, "L", false, 0, "")
+	}
+	if p.Bio != "" {
+		pdf.Ln(1)
+		setFont(pdf, "", 10, colorText)
+		pdf.MultiCell(0, 5, p.Bio, "", "L", false)
+	}
+
+	pdf.Ln(1)
+	setFont(pdf, "", 9, colorMuted)
+	pdf.MultiCell(0, 5, strings.Join(doc.summaryItems(), "  •  "), "", "L", false)
+
+	for _, link := range doc.links() {
+		setFont(pdf, "", 9, colorMuted)
+		pdf.Write(5, link.Label+": ")
+		setFont(pdf, "", 9, colorLink)
+		pdf.WriteLinkString(5, link.URL, link.URL)
+		pdf.Ln(5)
+	}
+	pdf.Ln(3)
+}
+
+// sectionTitle desenha o título da seção com a linha inferior
+func (r *pdfLayout) sectionTitle(title string) {
+	pdf := r.pdf
+	pdf.Ln(2)
+	setFont(pdf, "B", 12, colorTitle)
+	pdf.CellFormat(0, 7, title, "", 1, "L", false, 0, "")
+	left, _, _, _ := pdf.GetMargins()
+	y := pdf.GetY()
+	pdf.SetDrawColor(colorBorder[0], colorBorder[1], colorBorder[2])
+	pdf.Line(left, y, left+r.contentWidth(), y)
+	pdf.Ln(2)
+}
+
+// itemHeader escreve o título do item à esquerda e o período à direita
+func (r *pdfLayout) itemHeader(title, subtitle, period string) {
+	pdf := r.pdf
+	setFont(pdf, "", 9, colorMuted)
+	periodW := pdf.GetStringWidth(period) + 2
+
+	setFont(pdf, "B", 11, colorTitle)
+	pdf.CellFormat(r.contentWidth()-periodW, 6, title, "", 0, "L", false, 0, "")
+	setFont(pdf, "", 9, colorMuted)
+	pdf.CellFormat(periodW, 6, period, "", 1, "R", false, 0, "")
+
+	if subtitle != "" {
+		setFont(pdf, "", 10, colorText)
+		pdf.CellFormat(0, 5, subtitle, "", 1, "L", false, 0, "")
+	}
+}
+
+func (r *pdfLayout) skills(skills []string) {
+	if len(skills) == 0 {
+		return
+	}
+	r.sectionTitle(r.labels.skills)
+	setFont(r.pdf, "", 10, colorText)
+	r.pdf.MultiCell(0, 5, strings.Join(skills, " • "), "", "L", false)
+}
+
+func (r *pdfLayout) experiences(doc Document) {
+	if len(doc.Profile.Experiences) == 0 {
+		return
+	}
+	pdf := r.pdf
+	r.sectionTitle(r.labels.experience)
+	for _, exp := range doc.Profile.Experiences {
+		r.itemHeader(exp.Role, exp.Company, r.labels.formatPeriod(exp.StartDate, exp.EndDate))
+		if exp.Description != "" {
+			setFont(pdf, "", 10, colorText)
+			pdf.MultiCell(0, 5, exp.Description, "", "L", false)
+		}
+		if len(exp.TechStack) > 0 {
+			setFont(pdf, "", 9, colorMuted)
+			pdf.MultiCell(0, 5, strings.Join(exp.TechStack, ", "), "", "L", false)
+		}
+		pdf.Ln(3)
+	}
+}
+
+func (r *pdfLayout) projects(doc Document) {
+	if len(doc.Profile.Projects) == 0 {
+		return
+	}
+	pdf := r.pdf
+	r.sectionTitle(r.labels.projects)
+	for _, proj := range doc.Profile.Projects {
+		setFont(pdf, "B", 11, colorTitle)
+		pdf.CellFormat(0, 6, proj.Name, "", 1, "L", false, 0, "")
+		if proj.Description != "" {
+			setFont(pdf, "", 9, colorText)
+			pdf.MultiCell(0, 4.5, proj.Description, "", "L", false)
+		}
+		if len(proj.Tags) > 0 {
+			setFont(pdf, "", 9, colorMuted)
+			pdf.MultiCell(0, 4.5, strings.Join(proj.Tags, ", "), "", "L", false)
+		}
+		r.links(r.labels.projectLinks(proj))
+		pdf.Ln(3)
+	}
+}
+
+func (r *pdfLayout) educations(doc Document) {
+	if len(doc.Profile.Educations) == 0 {
+		return
+	}
+	r.sectionTitle(r.labels.education)
+	for _, edu := range doc.Profile.Educations {
+		r.itemHeader(r.labels.educationTitle(edu), edu.Institution, r.labels.formatPeriod(edu.StartDate, edu.EndDate))
+		r.pdf.Ln(2)
+	}
+}
+
+// links escreve os links de um item em uma única linha
+func (r *pdfLayout) links(links []Link) {
+	if len(links) == 0 {
+		return
+	}
+	pdf := r.pdf
+	setFont(pdf, "", 8, colorLink)
+	for i, link := range links {
+		if i > 0 {
+			pdf.Write(4.5, "    ")
+		}
+		pdf.WriteLinkString(4.5, link.Label, link.URL)
+	}
+	pdf.Ln(4.5)
+}
+
+func (r *pdfLayout) certifications(doc Document) {
+	if len(doc.Profile.Certifications) == 0 {
+		return
+	}
+	r.sectionTitle(r.labels.certifications)
+	for _, cert := range doc.Profile.Certifications {
+		r.itemHeader(cert.Name, r.labels.certificationSubtitle(cert), r.labels.certificationPeriod(cert))
+		r.links(r.labels.certificationLinks(cert))
+		r.pdf.Ln(2)
+	}
+}
+
+func (r *pdfLayout) languages(doc Document) {
+	if len(doc.Profile.Languages) == 0 {
+		return
+	}
+	r.sectionTitle(r.labels.languages)
+	setFont(r.pdf, "", 10, colorText)
+	r.pdf.MultiCell(0, 5, strings.Join(r.labels.languageItems(doc.Profile.Languages), " • "), "", "L", false)
+}
+
+func (r *pdfLayout) publications(doc Document) {
+	if len(doc.Profile.Publications) == 0 {
+		return
+	}
+	pdf := r.pdf
+	r.sectionTitle(r.labels.publications)
+	for _, pub := range doc.Profile.Publications {
+		r.itemHeader(pub.Title, r.labels.publicationSubtitle(pub), r.labels.month(pub.Date))
+		if pub.Description != "" {
+			setFont(pdf, "", 9, colorText)
+			pdf.MultiCell(0, 4.5, pub.Description, "", "L", false)
+		}
+		r.links(r.labels.publicationLinks(pub))
+		pdf.Ln(2)
+	}
+}
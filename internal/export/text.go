@@ -0,0 +1,162 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// textWidth é a largura de quebra de linha do formato texto
+const textWidth = 80
+
+type textFormat struct{}
+
+func (textFormat) Name() string      { return "text" }
+func (textFormat) MediaType() string { return "text/plain" }
+func (textFormat) Extension() string { return "txt" }
+
+// wrapText quebra o texto em linhas de até width caracteres, com recuo opcional.
+// Linhas em branco do original são mantidas como separação de parágrafos.
+func wrapText(s string, width int, indent string) string {
+	var b strings.Builder
+	for i, paragraph := range strings.Split(cleanText(s), "\n") {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		lineLen := 0
+		for j, word := range strings.Fields(paragraph) {
+			wordLen := utf8.RuneCountInString(word)
+			switch {
+			case j == 0:
+				b.WriteString(indent)
+				lineLen = len(indent)
+			case lineLen+1+wordLen > width:
+				b.WriteString("\n" + indent)
+				lineLen = len(indent)
+			default:
+				b.WriteByte(' ')
+				lineLen++
+			}
+			b.WriteString(word)
+			lineLen += wordLen
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func textHeading(out io.Writer, title string) {
+	title = strings.ToUpper(title)
+	fmt.Fprintf(out, "%s\n%s\n\n", title, strings.Repeat("-", utf8.RuneCountInString(title)))
+}
+
+func (textFormat) Render(w io.Writer, doc Document) error {
+	p := doc.Profile
+	l := doc.labels()
+	out := bufio.NewWriter(w)
+
+	title := strings.ToUpper(cleanText(doc.Title()))
+	fmt.Fprintf(out, "%s\n%s\n", title, strings.Repeat("=", utf8.RuneCountInString(title)))
+	if doc.OwnerName != "" && p.Headline != "" {
+		fmt.Fprintln(out, wrapText(p.Headline, textWidth, ""))
+	}
+	if p.OpenToWork {
+		fmt.Fprintln(out, l.openToWork)
+	}
+	fmt.Fprintln(out)
+	if p.Bio != "" {
+		fmt.Fprintf(out, "%s\n\n", wrapText(p.Bio, textWidth, ""))
+	}
+	fmt.Fprintf(out, "%s\n", wrapText(strings.Join(doc.summaryItems(), " • "), textWidth, ""))
+	for _, link := range doc.links() {
+		fmt.Fprintf(out, "%s: %s\n", link.Label, link.URL)
+	}
+	fmt.Fprintln(out)
+
+	if len(p.Skills) > 0 {
+		textHeading(out, l.skills)
+		fmt.Fprintf(out, "%s\n\n", wrapText(strings.Join(l.skillItems(p.Skills), " • "), textWidth, ""))
+	}
+
+	if len(p.Experiences) > 0 {
+		textHeading(out, l.experience)
+		for _, exp := range p.Experiences {
+			fmt.Fprintln(out, cleanText(joinNonEmpty(" - ", exp.Role, exp.Company)))
+			fmt.Fprintln(out, l.formatPeriod(exp.StartDate, exp.EndDate))
+			if exp.Description != "" {
+				fmt.Fprintln(out, wrapText(exp.Description, textWidth, "  "))
+			}
+			if len(exp.TechStack) > 0 {
+				fmt.Fprintln(out, wrapText(l.techStack+": "+strings.Join(exp.TechStack, ", "), textWidth, "  "))
+			}
+			fmt.Fprintln(out)
+		}
+	}
+
+	if len(p.Projects) > 0 {
+		textHeading(out, l.projects)
+		for _, proj := range p.Projects {
+			fmt.Fprintln(out, cleanText(proj.Name))
+			if proj.Description != "" {
+				fmt.Fprintln(out, wrapText(proj.Description, textWidth, "  "))
+			}
+			if len(proj.Tags) > 0 {
+				fmt.Fprintln(out, wrapText(l.tags+": "+strings.Join(proj.Tags, ", "), textWidth, "  "))
+			}
+			for _, link := range l.projectLinks(proj) {
+				fmt.Fprintf(out, "  %s: %s\n", link.Label, link.URL)
+			}
+			fmt.Fprintln(out)
+		}
+	}
+
+	if len(p.Educations) > 0 {
+		textHeading(out, l.education)
+		for _, edu := range p.Educations {
+			fmt.Fprintln(out, cleanText(l.educationTitle(edu)))
+			fmt.Fprintln(out, cleanText(edu.Institution))
+			fmt.Fprintf(out, "%s\n\n", l.formatPeriod(edu.StartDate, edu.EndDate))
+		}
+	}
+
+	if len(p.Certifications) > 0 {
+		textHeading(out, l.certifications)
+		for _, cert := range p.Certifications {
+			fmt.Fprintln(out, cleanText(cert.Name))
+			fmt.Fprintln(out, cleanText(l.certificationSubtitle(cert)))
+			fmt.Fprintln(out, l.certificationPeriod(cert))
+			for _, link := range l.certificationLinks(cert) {
+				fmt.Fprintf(out, "  %s: %s\n", link.Label, link.URL)
+			}
+			fmt.Fprintln(out)
+		}
+	}
+
+	if len(p.Languages) > 0 {
+		textHeading(out, l.languages)
+		for _, item := range l.languageItems(p.Languages) {
+			fmt.Fprintf(out, "- %s\n", item)
+		}
+		fmt.Fprintln(out)
+	}
+
+	if len(p.Publications) > 0 {
+		textHeading(out, l.publications)
+		for _, pub := range p.Publications {
+			fmt.Fprintln(out, cleanText(pub.Title))
+			fmt.Fprintln(out, cleanText(l.publicationSubtitle(pub)))
+			fmt.Fprintln(out, l.month(pub.Date))
+			if pub.Description != "" {
+				fmt.Fprintln(out, wrapText(pub.Description, textWidth, "  "))
+			}
+			for _, link := range l.publicationLinks(pub) {
+				fmt.Fprintf(out, "  %s: %s\n", link.Label, link.URL)
+			}
+			fmt.Fprintln(out)
+		}
+	}
+
+	fmt.Fprintf(out, "--\n%s\n", doc.footer())
+	return out.Flush()
+}
@@ -0,0 +1,175 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"portfolio/internal/auth"
+	"portfolio/internal/storage"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// AvatarVariants são as miniaturas geradas para cada avatar, da menor para a maior
+var AvatarVariants = []Variant{
+	{Name: "sm", Size: 64},
+	{Name: "md", Size: 128},
+	{Name: "lg", Size: 256},
+	{Name: "xl", Size: 512},
+}
+
+// DefaultAvatarVariant é o tamanho servido quando ?size= não é informado
+const DefaultAvatarVariant = "lg"
+
+type AvatarService struct {
+	store    storage.BlobStore
+	userRepo auth.UserRepository
+}
+
+func NewAvatarService(store storage.BlobStore, userRepo auth.UserRepository) *AvatarService {
+	return &AvatarService{
+		store:    store,
+		userRepo: userRepo,
+	}
+}
+
+// AvatarURL é a URL estável do avatar enviado: continua a mesma quando o usuário
+// troca de imagem, então pode ser gravada em users.profile_image
+func AvatarURL(userID string) string {
+	return "/media/avatars/" + userID
+}
+
+// avatarPrefix é o "diretório" de uma versão do avatar no BlobStore
+func avatarPrefix(userID, version string) string {
+	return "avatars/" + userID + "/" + version
+}
+
+// avatarBlobKey monta a chave da miniatura a partir de users.avatar_key ("{versão}.{ext}")
+func avatarBlobKey(userID, avatarKey, variant string) string {
+	version, ext, _ := strings.Cut(avatarKey, ".")
+	return avatarPrefix(userID, version) + "/" + variant + "." + ext
+}
+
+// UploadAvatar valida a imagem, gera as miniaturas e troca o avatar do usuário.
+// A versão anterior é removida do BlobStore depois que a nova foi salva.
+func (s *AvatarService) UploadAvatar(ctx context.Context, userID string, data []byte) (*auth.User, error) {
+	user, err := s.userRepo.Find(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	img, err := DecodeImage(data)
+	if err != nil {
+		return nil, err
+	}
+
+	version := strings.ReplaceAll(uuid.New().String(), "-", "")[:16]
+	ext := ""
+	for _, variant := range AvatarVariants {
+		encoded, variantExt, err := EncodeImage(SquareThumbnail(img, variant.Size))
+		if err != nil {
+			return nil, fmt.Errorf("encode avatar %s: %w", variant.Name, err)
+		}
+		ext = variantExt
+		key := avatarPrefix(userID, version) + "/" + variant.Name + "." + ext
+		if err := s.store.Put(ctx, key, bytes.NewReader(encoded), int64(len(encoded)), ""); err != nil {
+			s.removeVersion(ctx, userID, version)
+			return nil, fmt.Errorf("store avatar %s: %w", variant.Name, err)
+		}
+	}
+
+	previous := user.AvatarKey
+	avatarKey := version + "." + ext
+	avatarURL := AvatarURL(userID)
+	user.AvatarKey = &avatarKey
+	user.ProfileImage = &avatarURL
+	if err := s.userRepo.Save(ctx, user); err != nil {
+		s.removeVersion(ctx, userID, version)
+		return nil, err
+	}
+
+	if previous != nil {
+		oldVersion, _, _ := strings.Cut(*previous, ".")
+		s.removeVersion(ctx, userID, oldVersion)
+	}
+	return user, nil
+}
+
+// DeleteAvatar remove o avatar enviado; o usuário volta a ficar sem imagem
+// (ou com a do provedor OAuth no próximo login)
+func (s *AvatarService) DeleteAvatar(ctx context.Context, userID string) error {
+	user, err := s.userRepo.Find(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.AvatarKey == nil {
+		return ErrAvatarNotFound
+	}
+
+	version, _, _ := strings.Cut(*user.AvatarKey, ".")
+	user.AvatarKey = nil
+	user.ProfileImage = nil
+	if err := s.userRepo.Save(ctx, user); err != nil {
+		return err
+	}
+	s.removeVersion(ctx, userID, version)
+	return nil
+}
+
+// OpenAvatar abre a miniatura pedida. size aceita o nome ("sm", "lg") ou a
+// largura em pixels (usa a menor miniatura que a cobre). O etag muda a cada upload.
+func (s *AvatarService) OpenAvatar(ctx context.Context, userID, size string) (io.ReadCloser, storage.BlobInfo, string, error) {
+	user, err := s.userRepo.Find(ctx, userID)
+	if err != nil {
+		if errors.Is(err, auth.ErrUserNotFound) {
+			return nil, storage.BlobInfo{}, "", ErrAvatarNotFound
+		}
+		return nil, storage.BlobInfo{}, "", err
+	}
+	if user.AvatarKey == nil {
+		return nil, storage.BlobInfo{}, "", ErrAvatarNotFound
+	}
+
+	variant := avatarVariant(size)
+	rc, info, err := s.store.Get(ctx, avatarBlobKey(userID, *user.AvatarKey, variant))
+	if err != nil {
+		if errors.Is(err, storage.ErrBlobNotFound) {
+			return nil, storage.BlobInfo{}, "", ErrAvatarNotFound
+		}
+		return nil, storage.BlobInfo{}, "", err
+	}
+	etag := `"` + *user.AvatarKey + "-" + variant + `"`
+	return rc, info, etag, nil
+}
+
+// avatarVariant converte o ?size= em uma das variantes geradas
+func avatarVariant(size string) string {
+	if size == "" {
+		return DefaultAvatarVariant
+	}
+	for _, v := range AvatarVariants {
+		if v.Name == size {
+			return v.Name
+		}
+	}
+	if px, err := strconv.Atoi(size); err == nil {
+		for _, v := range AvatarVariants {
+			if v.Size >= px {
+				return v.Name
+			}
+		}
+		return AvatarVariants[len(AvatarVariants)-1].Name
+	}
+	return DefaultAvatarVariant
+}
+
+func (s *AvatarService) removeVersion(ctx context.Context, userID, version string) {
+	if err := s.store.DeletePrefix(ctx, avatarPrefix(userID, version)); err != nil {
+		log.Printf("Falha ao remover avatar antigo %s/%s: %v", userID, version, err)
+	}
+}
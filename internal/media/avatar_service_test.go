@@ -0,0 +1,33 @@
+package media
+
+import "testing"
+
+func TestAvatarVariant(t *testing.T) {
+	tests := []struct {
+		size string
+		want string
+	}{
+		{"", DefaultAvatarVariant},
+		{"sm", "sm"},
+		{"md", "md"},
+		{"lg", "lg"},
+		{"xl", "xl"},
+		{"1", "sm"},
+		{"64", "sm"},
+		{"65", "md"},
+		{"128", "md"},
+		{"200", "lg"},
+		{"512", "xl"},
+		{"4096", "xl"},
+		{"0", "sm"},
+		{"-10", "sm"},
+		{"huge", DefaultAvatarVariant},
+		{"LG", DefaultAvatarVariant},
+		{"../lg", DefaultAvatarVariant},
+	}
+	for _, tt := range tests {
+		if got := avatarVariant(tt.size); got != tt.want {
+			t.Errorf("avatarVariant(%q) = %q, want %q", tt.size, got, tt.want)
+		}
+	}
+}
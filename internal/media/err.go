@@ -0,0 +1,9 @@
+package media
+
+import "errors"
+
+var ErrUnsupportedImageType = errors.New("unsupported image type (use JPEG, PNG, GIF or WebP)")
+var ErrInvalidImage = errors.New("invalid image")
+var ErrImageDimensions = errors.New("image dimensions exceed the limit")
+var ErrAvatarNotFound = errors.New("avatar not found")
+var ErrMediaNotFound = errors.New("media not found")
@@ -0,0 +1,228 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	xdraw "golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// MaxImageUploadSize limita o tamanho do arquivo enviado
+const MaxImageUploadSize = 5 << 20
+
+// MaxImagePixels limita a resolução da imagem antes de decodificá-la (evita
+// "bombas" de descompressão: arquivos pequenos com dimensões gigantes)
+const MaxImagePixels = 40_000_000
+
+// jpegQuality é a qualidade usada nas miniaturas JPEG
+const jpegQuality = 85
+
+// allowedImageTypes são os tipos aceitos, detectados pelo conteúdo (não pela extensão)
+var allowedImageTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+// Variant é uma miniatura quadrada gerada a partir da imagem enviada
+type Variant struct {
+	Name string
+	Size int
+}
+
+// SniffImageType detecta o tipo da imagem pelos primeiros bytes do arquivo
+func SniffImageType(data []byte) (string, error) {
+	contentType := http.DetectContentType(data)
+	if !allowedImageTypes[contentType] {
+		return "", ErrUnsupportedImageType
+	}
+	return contentType, nil
+}
+
+// DecodeImage valida e decodifica a imagem, aplicando a orientação EXIF das
+// fotos de celular. Metadados (EXIF, GPS, ...) são descartados: apenas os
+// pixels seguem adiante e as miniaturas são recodificadas do zero.
+func DecodeImage(data []byte) (image.Image, error) {
+	contentType, err := SniffImageType(data)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > MaxImagePixels {
+		return nil, ErrImageDimensions
+	}
+
+	// Em GIFs animados apenas o primeiro quadro é usado
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+
+	if contentType == "image/jpeg" {
+		img = applyOrientation(img, jpegOrientation(data))
+	}
+	return img, nil
+}
+
+// SquareThumbnail recorta o centro da imagem em um quadrado e redimensiona para size×size
+func SquareThumbnail(src image.Image, size int) *image.RGBA {
+	b := src.Bounds()
+	side := min(b.Dx(), b.Dy())
+	crop := image.Rect(0, 0, side, side).Add(image.Pt(b.Min.X+(b.Dx()-side)/2, b.Min.Y+(b.Dy()-side)/2))
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), src, crop, draw.Src, nil)
+	return dst
+}
+
+// Fit redimensiona a imagem para caber em maxWidth×maxHeight mantendo a
+// proporção. Imagens menores não são ampliadas.
+func Fit(src image.Image, maxWidth, maxHeight int) *image.RGBA {
+	b := src.Bounds()
+	width, height := b.Dx(), b.Dy()
+	if width > maxWidth {
+		height = height * maxWidth / width
+		width = maxWidth
+	}
+	if height > maxHeight {
+		width = width * maxHeight / height
+		height = maxHeight
+	}
+	width, height = max(width, 1), max(height, 1)
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), src, b, draw.Src, nil)
+	return dst
+}
+
+// EncodeImage codifica a imagem em JPEG ou, se houver transparência, em PNG.
+// Retorna os bytes e a extensão usada.
+func EncodeImage(img *image.RGBA) ([]byte, string, error) {
+	var buf bytes.Buffer
+	if img.Opaque() {
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), "jpg", nil
+	}
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), "png", nil
+}
+
+// jpegOrientation lê a tag Orientation (0x0112) do segmento EXIF do JPEG.
+// Retorna 1 (normal) quando não há EXIF ou ele é inválido.
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return 1
+		}
+		marker := data[pos+1]
+		// Início dos dados da imagem: não há mais metadados
+		if marker == 0xDA || marker == 0xD9 {
+			return 1
+		}
+		length := int(binary.BigEndian.Uint16(data[pos+2:]))
+		if length < 2 || pos+2+length > len(data) {
+			return 1
+		}
+		segment := data[pos+4 : pos+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return exifOrientation(segment[6:])
+		}
+		pos += 2 + length
+	}
+	return 1
+}
+
+// exifOrientation procura a orientação no IFD0 do cabeçalho TIFF
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 1
+	}
+	entries := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < entries; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			orientation := int(order.Uint16(tiff[entry+8:]))
+			if orientation < 1 || orientation > 8 {
+				return 1
+			}
+			return orientation
+		}
+	}
+	return 1
+}
+
+// applyOrientation gira/espelha a imagem conforme a orientação EXIF (1 a 8)
+func applyOrientation(src image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return src
+	}
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	// Orientações 5 a 8 trocam largura e altura
+	dstW, dstH := w, h
+	if orientation >= 5 {
+		dstW, dstH = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // espelhado horizontalmente
+				dx, dy = w-1-x, y
+			case 3: // girado 180°
+				dx, dy = w-1-x, h-1-y
+			case 4: // espelhado verticalmente
+				dx, dy = x, h-1-y
+			case 5: // espelhado e girado 90° anti-horário
+				dx, dy = y, x
+			case 6: // girado 90° horário
+				dx, dy = h-1-y, x
+			case 7: // espelhado e girado 90° horário
+				dx, dy = h-1-y, w-1-x
+			case 8: // girado 90° anti-horário
+				dx, dy = y, w-1-x
+			}
+			dst.Set(dx, dy, src.At(b.Min.X+x, b.Min.Y+y))
+		}
+	}
+	return dst
+}
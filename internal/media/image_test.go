@@ -0,0 +1,193 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+// pngHeader monta um PNG com apenas a assinatura e o IHDR: suficiente para o
+// DecodeConfig, sem alocar os pixels declarados
+func pngHeader(width, height uint32) []byte {
+	ihdr := make([]byte, 13)
+	binary.BigEndian.PutUint32(ihdr[0:], width)
+	binary.BigEndian.PutUint32(ihdr[4:], height)
+	ihdr[8] = 8 // bits por canal
+	ihdr[9] = 2 // RGB
+
+	var buf bytes.Buffer
+	buf.WriteString("\x89PNG\r\n\x1a\n")
+	binary.Write(&buf, binary.BigEndian, uint32(len(ihdr)))
+	chunk := append([]byte("IHDR"), ihdr...)
+	buf.Write(chunk)
+	binary.Write(&buf, binary.BigEndian, crc32.ChecksumIEEE(chunk))
+	return buf.Bytes()
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// markedImage cria uma imagem w×h preta com o pixel (0, 0) vermelho
+func markedImage(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.Black)
+		}
+	}
+	img.Set(0, 0, color.RGBA{R: 255, A: 255})
+	return img
+}
+
+// jpegWithOrientation codifica a imagem em JPEG com um segmento EXIF contendo a orientação
+func jpegWithOrientation(t *testing.T, img image.Image, orientation uint16) []byte {
+	t.Helper()
+	var encoded bytes.Buffer
+	if err := jpeg.Encode(&encoded, img, &jpeg.Options{Quality: 100}); err != nil {
+		t.Fatal(err)
+	}
+
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	tiff = binary.BigEndian.AppendUint16(tiff, 1) // uma entrada no IFD0
+	tiff = binary.BigEndian.AppendUint16(tiff, 0x0112)
+	tiff = binary.BigEndian.AppendUint16(tiff, 3) // SHORT
+	tiff = binary.BigEndian.AppendUint32(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0)
+	tiff = binary.BigEndian.AppendUint32(tiff, 0) // sem próximo IFD
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+
+	var buf bytes.Buffer
+	buf.Write(encoded.Bytes()[:2]) // SOI
+	buf.Write([]byte{0xFF, 0xE1})
+	binary.Write(&buf, binary.BigEndian, uint16(len(segment)+2))
+	buf.Write(segment)
+	buf.Write(encoded.Bytes()[2:])
+	return buf.Bytes()
+}
+
+func TestSniffImageType(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want string
+		err  error
+	}{
+		{"png", encodePNG(t, markedImage(2, 2)), "image/png", nil},
+		{"gif", []byte("GIF89a\x01\x00\x01\x00\x00\x00\x00"), "image/gif", nil},
+		{"texto", []byte("não é uma imagem"), "", ErrUnsupportedImageType},
+		{"pdf", []byte("%PDF-1.7\n"), "", ErrUnsupportedImageType},
+		{"svg", []byte(`<svg xmlns="http://www.w3.org/2000/svg"></svg>`), "", ErrUnsupportedImageType},
+		{"vazio", nil, "", ErrUnsupportedImageType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SniffImageType(tt.data)
+			if !errors.Is(err, tt.err) || got != tt.want {
+				t.Errorf("SniffImageType() = %q, %v, want %q, %v", got, err, tt.want, tt.err)
+			}
+		})
+	}
+}
+
+func TestDecodeImage(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		err  error
+	}{
+		{"imagem válida", encodePNG(t, markedImage(3, 2)), nil},
+		{"bomba de pixels", pngHeader(10_000, 10_000), ErrImageDimensions},
+		{"logo acima do limite", pngHeader(MaxImagePixels/1000+1, 1000), ErrImageDimensions},
+		{"cabeçalho sem pixels", pngHeader(10, 10), ErrInvalidImage},
+		{"png truncado", encodePNG(t, markedImage(3, 2))[:20], ErrInvalidImage},
+		{"arquivo que não é imagem", []byte("hello, world"), ErrUnsupportedImageType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img, err := DecodeImage(tt.data)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("DecodeImage() error = %v, want %v", err, tt.err)
+			}
+			if err == nil && img.Bounds().Dx() != 3 {
+				t.Errorf("width = %d, want 3", img.Bounds().Dx())
+			}
+		})
+	}
+}
+
+func TestJpegOrientation(t *testing.T) {
+	img := markedImage(4, 2)
+	for _, orientation := range []uint16{1, 3, 6, 8} {
+		if got := jpegOrientation(jpegWithOrientation(t, img, orientation)); got != int(orientation) {
+			t.Errorf("jpegOrientation(%d) = %d", orientation, got)
+		}
+	}
+
+	var plain bytes.Buffer
+	if err := jpeg.Encode(&plain, img, nil); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"sem exif", plain.Bytes()},
+		{"orientação inválida", jpegWithOrientation(t, img, 9)},
+		{"não é jpeg", encodePNG(t, img)},
+		{"truncado", []byte{0xFF, 0xD8, 0xFF}},
+	}
+	for _, tt := range tests {
+		if got := jpegOrientation(tt.data); got != 1 {
+			t.Errorf("%s: jpegOrientation() = %d, want 1", tt.name, got)
+		}
+	}
+}
+
+func TestApplyOrientation(t *testing.T) {
+	// Imagem 3×2 com o pixel vermelho no canto superior esquerdo
+	tests := []struct {
+		orientation   int
+		width, height int
+		markX, markY  int
+	}{
+		{1, 3, 2, 0, 0},
+		{3, 3, 2, 2, 1},
+		{6, 2, 3, 1, 0},
+		{8, 2, 3, 0, 2},
+	}
+	red := color.RGBAModel.Convert(color.RGBA{R: 255, A: 255})
+	for _, tt := range tests {
+		got := applyOrientation(markedImage(3, 2), tt.orientation)
+		b := got.Bounds()
+		if b.Dx() != tt.width || b.Dy() != tt.height {
+			t.Errorf("orientation %d: size = %dx%d, want %dx%d", tt.orientation, b.Dx(), b.Dy(), tt.width, tt.height)
+			continue
+		}
+		if c := color.RGBAModel.Convert(got.At(tt.markX, tt.markY)); c != red {
+			t.Errorf("orientation %d: pixel (%d, %d) = %v, want red", tt.orientation, tt.markX, tt.markY, c)
+		}
+	}
+}
+
+func TestDecodeImageAppliesOrientation(t *testing.T) {
+	img, err := DecodeImage(jpegWithOrientation(t, markedImage(4, 2), 6))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := img.Bounds(); b.Dx() != 2 || b.Dy() != 4 {
+		t.Errorf("size = %dx%d, want 2x4", b.Dx(), b.Dy())
+	}
+}
@@ -0,0 +1,105 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"portfolio/internal/portfolio"
+	"portfolio/internal/storage"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Tamanhos gerados para imagens de projetos (mantendo a proporção)
+const (
+	projectImageMaxSize = 1600
+	projectThumbMaxSize = 480
+)
+
+// projectFilePattern valida o nome do arquivo na URL pública
+var projectFilePattern = regexp.MustCompile(`^(full|thumb)\.(jpg|png)$`)
+
+// UploadedImage descreve uma imagem de projeto pronta para entrar em Project.Media
+type UploadedImage struct {
+	Kind     portfolio.MediaKind `json:"kind"`
+	URL      string              `json:"url"`
+	ThumbURL string              `json:"thumbUrl"`
+	Width    int                 `json:"width"`
+	Height   int                 `json:"height"`
+}
+
+// ProjectMediaService guarda as imagens das galerias de projetos. Os arquivos são
+// imutáveis (cada upload gera um ID novo), então podem ser cacheados para sempre.
+// Imagens removidas do rascunho continuam no BlobStore porque a versão publicada
+// do perfil pode ainda referenciá-las.
+type ProjectMediaService struct {
+	store storage.BlobStore
+}
+
+func NewProjectMediaService(store storage.BlobStore) *ProjectMediaService {
+	return &ProjectMediaService{store: store}
+}
+
+func projectMediaKey(userID, mediaID, file string) string {
+	return "projects/" + userID + "/" + mediaID + "/" + file
+}
+
+// UploadProjectImage valida a imagem, remove metadados e grava a versão
+// grande e a miniatura
+func (s *ProjectMediaService) UploadProjectImage(ctx context.Context, userID string, data []byte) (*UploadedImage, error) {
+	img, err := DecodeImage(data)
+	if err != nil {
+		return nil, err
+	}
+
+	mediaID := strings.ReplaceAll(uuid.New().String(), "-", "")
+	full := Fit(img, projectImageMaxSize, projectImageMaxSize)
+	thumb := Fit(img, projectThumbMaxSize, projectThumbMaxSize)
+
+	uploaded := &UploadedImage{
+		Kind:   portfolio.MediaImage,
+		Width:  full.Bounds().Dx(),
+		Height: full.Bounds().Dy(),
+	}
+	fullData, ext, err := EncodeImage(full)
+	if err != nil {
+		return nil, fmt.Errorf("encode project image: %w", err)
+	}
+	thumbData, thumbExt, err := EncodeImage(thumb)
+	if err != nil {
+		return nil, fmt.Errorf("encode project thumbnail: %w", err)
+	}
+
+	fullKey := projectMediaKey(userID, mediaID, "full."+ext)
+	thumbKey := projectMediaKey(userID, mediaID, "thumb."+thumbExt)
+	if err := s.store.Put(ctx, fullKey, bytes.NewReader(fullData), int64(len(fullData)), ""); err != nil {
+		return nil, fmt.Errorf("store project image: %w", err)
+	}
+	if err := s.store.Put(ctx, thumbKey, bytes.NewReader(thumbData), int64(len(thumbData)), ""); err != nil {
+		s.store.Delete(ctx, fullKey)
+		return nil, fmt.Errorf("store project thumbnail: %w", err)
+	}
+
+	uploaded.URL = "/media/" + fullKey
+	uploaded.ThumbURL = "/media/" + thumbKey
+	return uploaded, nil
+}
+
+// OpenProjectImage abre um arquivo da galeria pelo caminho da URL pública
+func (s *ProjectMediaService) OpenProjectImage(ctx context.Context, userID, mediaID, file string) (io.ReadCloser, storage.BlobInfo, error) {
+	if !projectFilePattern.MatchString(file) {
+		return nil, storage.BlobInfo{}, ErrMediaNotFound
+	}
+	rc, info, err := s.store.Get(ctx, projectMediaKey(userID, mediaID, file))
+	if err != nil {
+		if errors.Is(err, storage.ErrBlobNotFound) || errors.Is(err, storage.ErrInvalidBlobKey) {
+			return nil, storage.BlobInfo{}, ErrMediaNotFound
+		}
+		return nil, storage.BlobInfo{}, err
+	}
+	return rc, info, nil
+}
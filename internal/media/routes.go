@@ -0,0 +1,219 @@
+package media
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"portfolio/internal/jwt"
+	"portfolio/internal/storage"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+type MediaModule struct {
+	avatarService       *AvatarService
+	projectMediaService *ProjectMediaService
+	jwtService          *jwt.JWTService
+}
+
+func NewMediaModule(avatarService *AvatarService, projectMediaService *ProjectMediaService, jwtService *jwt.JWTService) *MediaModule {
+	return &MediaModule{
+		avatarService:       avatarService,
+		projectMediaService: projectMediaService,
+		jwtService:          jwtService,
+	}
+}
+
+type AvatarResponse struct {
+	ProfileImage string            `json:"profileImage"`
+	Variants     map[string]string `json:"variants"`
+}
+
+func (module *MediaModule) RegisterRoutes() *mux.Router {
+	router := mux.NewRouter()
+
+	router.HandleFunc("/me/avatar", module.jwtService.RequiredAutenticationMiddleware(module.uploadAvatar)).Methods("POST")
+	router.HandleFunc("/me/avatar", module.jwtService.RequiredAutenticationMiddleware(module.deleteAvatar)).Methods("DELETE")
+	// URL estável do avatar (?size=sm|md|lg|xl ou largura em pixels)
+	router.HandleFunc("/avatars/{user_id}", module.getAvatar).Methods("GET")
+
+	// Galerias de projetos: o upload devolve o item a ser incluído em project.media
+	router.HandleFunc("/me/projects/images", module.jwtService.RequiredAutenticationMiddleware(module.uploadProjectImage)).Methods("POST")
+	router.HandleFunc("/projects/{user_id}/{media_id}/{file}", module.getProjectImage).Methods("GET")
+
+	return router
+}
+
+// uploadAvatar recebe a imagem no campo "file" (multipart)
+func (module *MediaModule) uploadAvatar(w http.ResponseWriter, r *http.Request) {
+	user := jwt.GetUserCurrentUser(r.Context())
+
+	data, ok := readImageUpload(w, r)
+	if !ok {
+		return
+	}
+
+	updated, err := module.avatarService.UploadAvatar(r.Context(), user.ID, data)
+	if err != nil {
+		if !writeImageError(w, err) {
+			log.Printf("UploadAvatar error: %v", err)
+			http.Error(w, "Failed to upload avatar", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	response := AvatarResponse{
+		ProfileImage: *updated.ProfileImage,
+		Variants:     make(map[string]string, len(AvatarVariants)),
+	}
+	for _, v := range AvatarVariants {
+		response.Variants[v.Name] = *updated.ProfileImage + "?size=" + v.Name
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+func (module *MediaModule) deleteAvatar(w http.ResponseWriter, r *http.Request) {
+	user := jwt.GetUserCurrentUser(r.Context())
+
+	if err := module.avatarService.DeleteAvatar(r.Context(), user.ID); err != nil {
+		if errors.Is(err, ErrAvatarNotFound) {
+			http.Error(w, "Avatar not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("DeleteAvatar error: %v", err)
+		http.Error(w, "Failed to delete avatar", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (module *MediaModule) getAvatar(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["user_id"]
+
+	rc, info, etag, err := module.avatarService.OpenAvatar(r.Context(), userID, r.URL.Query().Get("size"))
+	if err != nil {
+		if errors.Is(err, ErrAvatarNotFound) {
+			http.Error(w, "Avatar not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("OpenAvatar error: %v", err)
+		http.Error(w, "Failed to load avatar", http.StatusInternalServerError)
+		return
+	}
+	defer rc.Close()
+
+	// A URL é estável, então o navegador revalida com frequência (ETag muda a cada upload)
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	writeBlob(w, rc, info)
+}
+
+// uploadProjectImage recebe uma imagem da galeria no campo "file" (multipart)
+func (module *MediaModule) uploadProjectImage(w http.ResponseWriter, r *http.Request) {
+	user := jwt.GetUserCurrentUser(r.Context())
+
+	data, ok := readImageUpload(w, r)
+	if !ok {
+		return
+	}
+
+	uploaded, err := module.projectMediaService.UploadProjectImage(r.Context(), user.ID, data)
+	if err != nil {
+		if !writeImageError(w, err) {
+			log.Printf("UploadProjectImage error: %v", err)
+			http.Error(w, "Failed to upload image", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(uploaded); err != nil {
+		log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+func (module *MediaModule) getProjectImage(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	rc, info, err := module.projectMediaService.OpenProjectImage(r.Context(), vars["user_id"], vars["media_id"], vars["file"])
+	if err != nil {
+		if errors.Is(err, ErrMediaNotFound) {
+			http.Error(w, "Media not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("OpenProjectImage error: %v", err)
+		http.Error(w, "Failed to load image", http.StatusInternalServerError)
+		return
+	}
+	defer rc.Close()
+
+	// Cada upload tem URL própria, então o arquivo nunca muda
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	writeBlob(w, rc, info)
+}
+
+// readImageUpload lê o campo "file" respeitando MaxImageUploadSize.
+// Em caso de erro já respondeu a requisição e retorna ok=false.
+func readImageUpload(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	// Folga para os cabeçalhos do multipart
+	r.Body = http.MaxBytesReader(w, r.Body, MaxImageUploadSize+64<<10)
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			http.Error(w, "Image exceeds the maximum size of "+strconv.Itoa(MaxImageUploadSize>>20)+" MB", http.StatusRequestEntityTooLarge)
+			return nil, false
+		}
+		http.Error(w, "Missing file", http.StatusBadRequest)
+		return nil, false
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, MaxImageUploadSize+1))
+	if err != nil {
+		http.Error(w, "Failed to read file", http.StatusBadRequest)
+		return nil, false
+	}
+	if len(data) > MaxImageUploadSize {
+		http.Error(w, "Image exceeds the maximum size of "+strconv.Itoa(MaxImageUploadSize>>20)+" MB", http.StatusRequestEntityTooLarge)
+		return nil, false
+	}
+	return data, true
+}
+
+// writeImageError responde os erros de validação de imagem; retorna false para erros internos
+func writeImageError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, ErrUnsupportedImageType):
+		http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+	case errors.Is(err, ErrInvalidImage), errors.Is(err, ErrImageDimensions):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		return false
+	}
+	return true
+}
+
+func writeBlob(w http.ResponseWriter, rc io.Reader, info storage.BlobInfo) {
+	w.Header().Set("Content-Type", info.ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if info.Size > 0 {
+		w.Header().Set("Content-Length", strconv.FormatInt(info.Size, 10))
+	}
+	if _, err := io.Copy(w, rc); err != nil {
+		log.Printf("Failed to write media: %v", err)
+	}
+}
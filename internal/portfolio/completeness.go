@@ -0,0 +1,56 @@
+package portfolio
+
+import "strings"
+
+const (
+	minBioLength   = 100
+	minSkillsCount = 5
+)
+
+// CompletenessItem é um item do checklist de preenchimento do perfil
+type CompletenessItem struct {
+	Key    string `json:"key"`
+	Label  string `json:"label"`
+	Weight int    `json:"weight"`
+	Done   bool   `json:"done"`
+}
+
+// Completeness resume o quanto o perfil está preenchido (0 a 100)
+type Completeness struct {
+	Score   int                `json:"score"`
+	Items   []CompletenessItem `json:"items"`
+	Missing []CompletenessItem `json:"missing"`
+}
+
+// Completeness calcula a pontuação de preenchimento. A foto pertence ao usuário,
+// por isso hasAvatar é informado pelo chamador. Os pesos somam 100.
+func (p *Profile) Completeness(hasAvatar bool) Completeness {
+	items := []CompletenessItem{
+		{Key: "headline", Label: "Adicione um título profissional", Weight: 15, Done: strings.TrimSpace(p.Headline) != ""},
+		{Key: "bio", Label: "Escreva uma bio com pelo menos 100 caracteres", Weight: 15, Done: len([]rune(strings.TrimSpace(p.Bio))) >= minBioLength},
+		{Key: "skills", Label: "Liste pelo menos 5 habilidades", Weight: 15, Done: len(p.Skills) >= minSkillsCount},
+		{Key: "experience", Label: "Adicione pelo menos uma experiência", Weight: 20, Done: len(p.Experiences) > 0},
+		{Key: "projects", Label: "Adicione um projeto com link (repositório ou demo)", Weight: 15, Done: p.hasLinkedProject()},
+		{Key: "education", Label: "Adicione sua formação", Weight: 10, Done: len(p.Educations) > 0},
+		{Key: "avatar", Label: "Adicione uma foto de perfil", Weight: 10, Done: hasAvatar},
+	}
+
+	c := Completeness{Items: items, Missing: []CompletenessItem{}}
+	for _, item := range items {
+		if item.Done {
+			c.Score += item.Weight
+		} else {
+			c.Missing = append(c.Missing, item)
+		}
+	}
+	return c
+}
+
+func (p *Profile) hasLinkedProject() bool {
+	for _, proj := range p.Projects {
+		if proj.RepoURL != "" || proj.LiveURL != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+package portfolio
+
+import (
+	"strings"
+	"time"
+
+	"portfolio/internal/taxonomy"
+)
+
+const maxRecommendationLength = 1000
+
+// SkillEndorsement é o endosso de uma habilidade do perfil por outro usuário
+type SkillEndorsement struct {
+	ID             string    `json:"id"`
+	ProfileID      string    `json:"profileId"`
+	EndorserUserID string    `json:"endorserUserId"`
+	Skill          string    `json:"skill"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+// EndorsementSummary é a contagem de endossos de uma habilidade do perfil
+type EndorsementSummary struct {
+	Skill string `json:"skill"`
+	Count int    `json:"count"`
+	// ViewerEndorsementID é o endosso do usuário logado, usado para desfazê-lo
+	ViewerEndorsementID string `json:"viewerEndorsementId,omitempty"`
+}
+
+// Endorsements são os endossos agrupados por habilidade, na ordem das habilidades do perfil
+type Endorsements []EndorsementSummary
+
+// For retorna os endossos da habilidade (contagem zero se não houver nenhum)
+func (e Endorsements) For(skill string) EndorsementSummary {
+	key := taxonomy.Key(skill)
+	for _, summary := range e {
+		if taxonomy.Key(summary.Skill) == key {
+			return summary
+		}
+	}
+	return EndorsementSummary{Skill: skill}
+}
+
+// Total soma os endossos de todas as habilidades (usado no ranking da busca)
+func (e Endorsements) Total() int {
+	total := 0
+	for _, summary := range e {
+		total += summary.Count
+	}
+	return total
+}
+
+// RecommendationStatus é o estado de moderação de uma recomendação
+type RecommendationStatus string
+
+const (
+	RecommendationPending  RecommendationStatus = "PENDING"
+	RecommendationApproved RecommendationStatus = "APPROVED"
+	RecommendationRejected RecommendationStatus = "REJECTED"
+)
+
+func (s RecommendationStatus) IsValid() bool {
+	switch s {
+	case RecommendationPending, RecommendationApproved, RecommendationRejected:
+		return true
+	}
+	return false
+}
+
+// Label retorna a descrição do estado exibida na moderação
+func (s RecommendationStatus) Label() string {
+	switch s {
+	case RecommendationPending:
+		return "Aguardando aprovação"
+	case RecommendationApproved:
+		return "Aprovada"
+	case RecommendationRejected:
+		return "Recusada"
+	default:
+		return string(s)
+	}
+}
+
+// Recommendation é um depoimento escrito por alguém que trabalhou na mesma
+// empresa que o dono do perfil. Só aparece no perfil depois de aprovada.
+type Recommendation struct {
+	ID           string `json:"id"`
+	ProfileID    string `json:"profileId"`
+	AuthorUserID string `json:"authorUserId"`
+	// Nome do autor (lido da tabela de usuários)
+	AuthorFirstName string `json:"authorFirstName"`
+	AuthorLastName  string `json:"authorLastName"`
+	// Company é a empresa em comum, com a grafia usada no perfil recomendado
+	Company    string               `json:"company"`
+	AuthorRole string               `json:"authorRole,omitempty"`
+	Text       string               `json:"text"`
+	Status     RecommendationStatus `json:"status"`
+	CreatedAt  time.Time            `json:"createdAt"`
+	UpdatedAt  time.Time            `json:"updatedAt"`
+}
+
+// AuthorName retorna o nome completo do autor
+func (r Recommendation) AuthorName() string {
+	return strings.TrimSpace(r.AuthorFirstName + " " + r.AuthorLastName)
+}
+
+// RecommendationInput é o corpo aceito ao escrever uma recomendação
+type RecommendationInput struct {
+	Company string `json:"company"`
+	Text    string `json:"text"`
+}
+
+func (in RecommendationInput) Validate() error {
+	v := &validator{}
+	v.check(strings.TrimSpace(in.Company) != "", "/company", "obrigatório")
+	text := strings.TrimSpace(in.Text)
+	v.check(text != "", "/text", "obrigatório")
+	v.check(len([]rune(text)) <= maxRecommendationLength, "/text", "deve ter no máximo 1000 caracteres")
+	return v.err()
+}
+
+// companyKey normaliza o nome da empresa para comparação ("ACME  Ltda" = "acme ltda")
+func companyKey(company string) string {
+	return strings.ToLower(strings.Join(strings.Fields(company), " "))
+}
+
+// SharedCompanies retorna as empresas (com a grafia destas experiências) em que
+// o dono de other também trabalhou
+func (e Experiences) SharedCompanies(other Experiences) []string {
+	theirs := map[string]bool{}
+	for _, exp := range other {
+		if key := companyKey(exp.Company); key != "" {
+			theirs[key] = true
+		}
+	}
+	shared := []string{}
+	seen := map[string]bool{}
+	for _, exp := range e {
+		key := companyKey(exp.Company)
+		if theirs[key] && !seen[key] {
+			seen[key] = true
+			shared = append(shared, strings.TrimSpace(exp.Company))
+		}
+	}
+	return shared
+}
+
+// RoleAt retorna o cargo mais recente na empresa informada
+func (e Experiences) RoleAt(company string) string {
+	key := companyKey(company)
+	role := ""
+	var latest time.Time
+	for _, exp := range e {
+		if companyKey(exp.Company) == key && (role == "" || exp.StartDate.After(latest)) {
+			role = strings.TrimSpace(exp.Role)
+			latest = exp.StartDate
+		}
+	}
+	return role
+}
@@ -0,0 +1,208 @@
+package portfolio
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// EndorsementRepository guarda os endossos de habilidades e as recomendações
+type EndorsementRepository interface {
+	AddEndorsement(ctx context.Context, endorsement *SkillEndorsement, skillKey string) error
+	FindEndorsement(ctx context.Context, endorsementID string) (*SkillEndorsement, error)
+	RemoveEndorsement(ctx context.Context, endorsementID string) error
+	// SummarizeEndorsements agrupa os endossos do perfil pela chave da habilidade.
+	// viewerID preenche ViewerEndorsementID e pode ser vazio.
+	SummarizeEndorsements(ctx context.Context, profileID string, viewerID string) (map[string]EndorsementSummary, error)
+
+	CreateRecommendation(ctx context.Context, recommendation *Recommendation) error
+	FindRecommendation(ctx context.Context, recommendationID string) (*Recommendation, error)
+	// ListRecommendations lista as recomendações do perfil, as mais recentes
+	// primeiro. status vazio retorna todas.
+	ListRecommendations(ctx context.Context, profileID string, status RecommendationStatus) ([]Recommendation, error)
+	UpdateRecommendationStatus(ctx context.Context, recommendationID string, status RecommendationStatus, updatedAt time.Time) error
+	DeleteRecommendation(ctx context.Context, recommendationID string) error
+}
+
+type endorsementRepo struct {
+	db *sql.DB
+}
+
+func NewEndorsementRepository(db *sql.DB) EndorsementRepository {
+	return &endorsementRepo{db: db}
+}
+
+// AddEndorsement não faz nada se o usuário já endossou a habilidade; nesse caso
+// endorsement recebe o ID e a data do endosso existente
+func (r *endorsementRepo) AddEndorsement(ctx context.Context, endorsement *SkillEndorsement, skillKey string) error {
+	query := `
+		INSERT INTO skill_endorsements (id, profile_id, endorser_user_id, skill, skill_key, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (profile_id, endorser_user_id, skill_key) DO NOTHING
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		endorsement.ID, endorsement.ProfileID, endorsement.EndorserUserID,
+		endorsement.Skill, skillKey, endorsement.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	query = `
+		SELECT id, skill, created_at FROM skill_endorsements
+		WHERE profile_id = $1 AND endorser_user_id = $2 AND skill_key = $3
+	`
+	return r.db.QueryRowContext(ctx, query, endorsement.ProfileID, endorsement.EndorserUserID, skillKey).
+		Scan(&endorsement.ID, &endorsement.Skill, &endorsement.CreatedAt)
+}
+
+func (r *endorsementRepo) FindEndorsement(ctx context.Context, endorsementID string) (*SkillEndorsement, error) {
+	query := `
+		SELECT id, profile_id, endorser_user_id, skill, created_at
+		FROM skill_endorsements WHERE id = $1
+	`
+	e := &SkillEndorsement{}
+	err := r.db.QueryRowContext(ctx, query, endorsementID).
+		Scan(&e.ID, &e.ProfileID, &e.EndorserUserID, &e.Skill, &e.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrEndorsementNotFound
+		}
+		return nil, err
+	}
+	return e, nil
+}
+
+func (r *endorsementRepo) RemoveEndorsement(ctx context.Context, endorsementID string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM skill_endorsements WHERE id = $1`, endorsementID)
+	return err
+}
+
+func (r *endorsementRepo) SummarizeEndorsements(ctx context.Context, profileID string, viewerID string) (map[string]EndorsementSummary, error) {
+	query := `
+		SELECT skill_key, MIN(skill), COUNT(*),
+		       COALESCE(MAX(CASE WHEN endorser_user_id::text = $2 THEN id::text END), '')
+		FROM skill_endorsements
+		WHERE profile_id = $1
+		GROUP BY skill_key
+	`
+	rows, err := r.db.QueryContext(ctx, query, profileID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	summaries := map[string]EndorsementSummary{}
+	for rows.Next() {
+		var key string
+		var s EndorsementSummary
+		if err := rows.Scan(&key, &s.Skill, &s.Count, &s.ViewerEndorsementID); err != nil {
+			return nil, err
+		}
+		summaries[key] = s
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
+
+func (r *endorsementRepo) CreateRecommendation(ctx context.Context, rec *Recommendation) error {
+	query := `
+		INSERT INTO profile_recommendations (id, profile_id, author_user_id, company, author_role, body, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		rec.ID, rec.ProfileID, rec.AuthorUserID, rec.Company, rec.AuthorRole,
+		rec.Text, rec.Status, rec.CreatedAt, rec.UpdatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return ErrRecommendationExists
+		}
+		return err
+	}
+	return nil
+}
+
+// recommendationColumns lista as colunas lidas em todos os SELECTs de recomendação
+const recommendationColumns = `rc.id, rc.profile_id, rc.author_user_id, u.first_name, u.last_name,
+		       rc.company, rc.author_role, rc.body, rc.status, rc.created_at, rc.updated_at`
+
+func scanRecommendation(row rowScanner) (*Recommendation, error) {
+	rec := &Recommendation{}
+	err := row.Scan(
+		&rec.ID, &rec.ProfileID, &rec.AuthorUserID, &rec.AuthorFirstName, &rec.AuthorLastName,
+		&rec.Company, &rec.AuthorRole, &rec.Text, &rec.Status, &rec.CreatedAt, &rec.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
+
+func (r *endorsementRepo) FindRecommendation(ctx context.Context, recommendationID string) (*Recommendation, error) {
+	query := `
+		SELECT ` + recommendationColumns + `
+		FROM profile_recommendations rc
+		JOIN users u ON u.id = rc.author_user_id
+		WHERE rc.id = $1
+	`
+	rec, err := scanRecommendation(r.db.QueryRowContext(ctx, query, recommendationID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRecommendationNotFound
+		}
+		return nil, err
+	}
+	return rec, nil
+}
+
+func (r *endorsementRepo) ListRecommendations(ctx context.Context, profileID string, status RecommendationStatus) ([]Recommendation, error) {
+	query := `
+		SELECT ` + recommendationColumns + `
+		FROM profile_recommendations rc
+		JOIN users u ON u.id = rc.author_user_id
+		WHERE rc.profile_id = $1 AND ($2 = '' OR rc.status = $2)
+		ORDER BY rc.created_at DESC
+	`
+	rows, err := r.db.QueryContext(ctx, query, profileID, string(status))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	recommendations := []Recommendation{}
+	for rows.Next() {
+		rec, err := scanRecommendation(rows)
+		if err != nil {
+			return nil, err
+		}
+		recommendations = append(recommendations, *rec)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return recommendations, nil
+}
+
+func (r *endorsementRepo) UpdateRecommendationStatus(ctx context.Context, recommendationID string, status RecommendationStatus, updatedAt time.Time) error {
+	query := `UPDATE profile_recommendations SET status = $2, updated_at = $3 WHERE id = $1`
+	result, err := r.db.ExecContext(ctx, query, recommendationID, status, updatedAt)
+	if err != nil {
+		return err
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return ErrRecommendationNotFound
+	}
+	return nil
+}
+
+func (r *endorsementRepo) DeleteRecommendation(ctx context.Context, recommendationID string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM profile_recommendations WHERE id = $1`, recommendationID)
+	return err
+}
@@ -0,0 +1,264 @@
+package portfolio
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"portfolio/internal/taxonomy"
+
+	"github.com/google/uuid"
+)
+
+// GetEndorsements retorna os endossos das habilidades do perfil, na ordem em
+// que aparecem nele. Endossos de habilidades removidas do perfil são ignorados.
+func (s *PortfolioService) GetEndorsements(ctx context.Context, profile *Profile, viewerID string) (Endorsements, error) {
+	summaries, err := s.endorsements.SummarizeEndorsements(ctx, profile.ID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	endorsements := Endorsements{}
+	for _, skill := range profile.Skills {
+		if summary, ok := summaries[s.skillKey(skill.Name)]; ok {
+			summary.Skill = skill.Name
+			endorsements = append(endorsements, summary)
+		}
+	}
+	return endorsements, nil
+}
+
+// ListEndorsements retorna os endossos de um perfil visível para o usuário
+func (s *PortfolioService) ListEndorsements(ctx context.Context, profileID string, viewerID string) (Endorsements, error) {
+	profile, err := s.GetVisibleProfile(ctx, profileID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetEndorsements(ctx, profile, viewerID)
+}
+
+// EndorseSkill registra o endosso de uma habilidade da versão publicada do perfil.
+// Endossar de novo a mesma habilidade não tem efeito.
+func (s *PortfolioService) EndorseSkill(ctx context.Context, endorserID string, profileID string, skill string) (*EndorsementSummary, error) {
+	profile, err := s.GetVisibleProfile(ctx, profileID, endorserID)
+	if err != nil {
+		return nil, err
+	}
+	if profile.UserID == endorserID {
+		return nil, ErrCannotEndorseSelf
+	}
+
+	key := s.skillKey(skill)
+	name := ""
+	for _, existing := range profile.Skills {
+		if s.skillKey(existing.Name) == key {
+			name = existing.Name
+			break
+		}
+	}
+	if key == "" || name == "" {
+		return nil, ErrSkillNotOnProfile
+	}
+
+	endorsement := &SkillEndorsement{
+		ID:             uuid.New().String(),
+		ProfileID:      profile.ID,
+		EndorserUserID: endorserID,
+		Skill:          name,
+		CreatedAt:      time.Now(),
+	}
+	if err := s.endorsements.AddEndorsement(ctx, endorsement, key); err != nil {
+		return nil, err
+	}
+	go s.reindexPublished(profile.ID, profile.UserID)
+
+	endorsements, err := s.GetEndorsements(ctx, profile, endorserID)
+	if err != nil {
+		return nil, err
+	}
+	summary := endorsements.For(name)
+	return &summary, nil
+}
+
+// WithdrawEndorsement remove um endosso. Pode ser feito por quem endossou ou
+// pelo dono do perfil (moderação).
+func (s *PortfolioService) WithdrawEndorsement(ctx context.Context, userID string, endorsementID string) error {
+	if uuid.Validate(endorsementID) != nil {
+		return ErrEndorsementNotFound
+	}
+	endorsement, err := s.endorsements.FindEndorsement(ctx, endorsementID)
+	if err != nil {
+		return err
+	}
+	profile, err := s.repo.Find(ctx, endorsement.ProfileID)
+	if err != nil {
+		return err
+	}
+	if endorsement.EndorserUserID != userID && profile.UserID != userID {
+		return ErrEndorsementNotFound
+	}
+
+	if err := s.endorsements.RemoveEndorsement(ctx, endorsement.ID); err != nil {
+		return err
+	}
+	go s.reindexPublished(profile.ID, profile.UserID)
+	return nil
+}
+
+// skillKey identifica a habilidade independentemente de apelidos e grafia
+func (s *PortfolioService) skillKey(name string) string {
+	return taxonomy.Key(s.taxonomy.Canonical(name))
+}
+
+// GetApprovedRecommendations retorna as recomendações exibidas no perfil
+func (s *PortfolioService) GetApprovedRecommendations(ctx context.Context, profileID string) ([]Recommendation, error) {
+	return s.endorsements.ListRecommendations(ctx, profileID, RecommendationApproved)
+}
+
+// ListRecommendations retorna as recomendações aprovadas de um perfil visível para o usuário
+func (s *PortfolioService) ListRecommendations(ctx context.Context, profileID string, viewerID string) ([]Recommendation, error) {
+	profile, err := s.GetVisibleProfile(ctx, profileID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetApprovedRecommendations(ctx, profile.ID)
+}
+
+// RecommendationCompanies retorna as empresas em que o usuário e o dono do perfil
+// trabalharam, ou seja, as que permitem escrever uma recomendação. Vazio para
+// visitantes anônimos e para o próprio dono.
+func (s *PortfolioService) RecommendationCompanies(ctx context.Context, profile *Profile, userID string) ([]string, error) {
+	if userID == "" || userID == profile.UserID {
+		return []string{}, nil
+	}
+	experiences, err := s.experiencesOf(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return profile.Experiences.SharedCompanies(experiences), nil
+}
+
+// experiencesOf junta as experiências de todos os perfis do usuário
+func (s *PortfolioService) experiencesOf(ctx context.Context, userID string) (Experiences, error) {
+	profiles, err := s.repo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	experiences := Experiences{}
+	for _, p := range profiles {
+		experiences = append(experiences, p.Experiences...)
+	}
+	return experiences, nil
+}
+
+// RecommendProfile cria uma recomendação pendente de aprovação do dono do perfil.
+// O autor precisa ter trabalhado na empresa informada, que deve estar entre as
+// experiências publicadas do perfil.
+func (s *PortfolioService) RecommendProfile(ctx context.Context, authorID string, profileID string, input RecommendationInput) (*Recommendation, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+	profile, err := s.GetVisibleProfile(ctx, profileID, authorID)
+	if err != nil {
+		return nil, err
+	}
+	if profile.UserID == authorID {
+		return nil, ErrCannotEndorseSelf
+	}
+
+	authorExperiences, err := s.experiencesOf(ctx, authorID)
+	if err != nil {
+		return nil, err
+	}
+	company := ""
+	for _, shared := range profile.Experiences.SharedCompanies(authorExperiences) {
+		if companyKey(shared) == companyKey(input.Company) {
+			company = shared
+		}
+	}
+	if company == "" {
+		return nil, ErrNoSharedExperience
+	}
+
+	author, err := s.userRepo.Find(ctx, authorID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	rec := &Recommendation{
+		ID:              uuid.New().String(),
+		ProfileID:       profile.ID,
+		AuthorUserID:    authorID,
+		AuthorFirstName: author.FirstName,
+		AuthorLastName:  author.LastName,
+		Company:         company,
+		AuthorRole:      authorExperiences.RoleAt(company),
+		Text:            strings.TrimSpace(input.Text),
+		Status:          RecommendationPending,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if err := s.endorsements.CreateRecommendation(ctx, rec); err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
+
+// ListReceivedRecommendations retorna as recomendações recebidas pelo perfil do
+// usuário para moderação. status vazio retorna todas.
+func (s *PortfolioService) ListReceivedRecommendations(ctx context.Context, userID string, profileID string, status RecommendationStatus) ([]Recommendation, error) {
+	profile, err := s.findOwned(ctx, userID, profileID)
+	if err != nil {
+		return nil, err
+	}
+	return s.endorsements.ListRecommendations(ctx, profile.ID, status)
+}
+
+// ModerateRecommendation aprova ou recusa uma recomendação recebida. Uma
+// recomendação aprovada pode ser recusada depois para sair do perfil.
+func (s *PortfolioService) ModerateRecommendation(ctx context.Context, userID string, recommendationID string, status RecommendationStatus) (*Recommendation, error) {
+	if status != RecommendationApproved && status != RecommendationRejected {
+		return nil, ErrInvalidModerationStatus
+	}
+	rec, profile, err := s.findRecommendation(ctx, recommendationID)
+	if err != nil {
+		return nil, err
+	}
+	if profile.UserID != userID {
+		return nil, ErrRecommendationNotFound
+	}
+
+	rec.Status = status
+	rec.UpdatedAt = time.Now()
+	if err := s.endorsements.UpdateRecommendationStatus(ctx, rec.ID, rec.Status, rec.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
+
+// DeleteRecommendation remove a recomendação. Pode ser feito pelo autor ou pelo dono do perfil.
+func (s *PortfolioService) DeleteRecommendation(ctx context.Context, userID string, recommendationID string) error {
+	rec, profile, err := s.findRecommendation(ctx, recommendationID)
+	if err != nil {
+		return err
+	}
+	if rec.AuthorUserID != userID && profile.UserID != userID {
+		return ErrRecommendationNotFound
+	}
+	return s.endorsements.DeleteRecommendation(ctx, rec.ID)
+}
+
+// findRecommendation busca a recomendação e o perfil recomendado
+func (s *PortfolioService) findRecommendation(ctx context.Context, recommendationID string) (*Recommendation, *Profile, error) {
+	if uuid.Validate(recommendationID) != nil {
+		return nil, nil, ErrRecommendationNotFound
+	}
+	rec, err := s.endorsements.FindRecommendation(ctx, recommendationID)
+	if err != nil {
+		return nil, nil, err
+	}
+	profile, err := s.repo.Find(ctx, rec.ProfileID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rec, profile, nil
+}
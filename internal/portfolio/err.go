@@ -4,4 +4,20 @@ import "errors"
 
 var ErrProfileNotFound = errors.New("profile not found")
 var ErrProfileAlreadyExists = errors.New("profile already exists for this user")
-var ErrInvalidProfileData = errors.New("invalid profile data")
\ No newline at end of file
+var ErrInvalidProfileData = errors.New("invalid profile data")
+var ErrProfileRestricted = errors.New("profile is only visible to authenticated recruiters")
+var ErrInvalidSlug = errors.New("slug must have 3 to 40 lowercase letters, numbers or single hyphens")
+var ErrSlugReserved = errors.New("slug is reserved")
+var ErrSlugTaken = errors.New("slug is already in use")
+var ErrProfileLimitReached = errors.New("maximum number of profiles reached")
+var ErrUnsupportedExportFormat = errors.New("unsupported export format")
+var ErrInvalidImportFile = errors.New("invalid import file")
+var ErrEndorsementNotFound = errors.New("endorsement not found")
+var ErrRecommendationNotFound = errors.New("recommendation not found")
+var ErrRecommendationExists = errors.New("you have already recommended this profile")
+var ErrCannotEndorseSelf = errors.New("you cannot endorse or recommend your own profile")
+var ErrSkillNotOnProfile = errors.New("skill is not listed on this profile")
+var ErrNoSharedExperience = errors.New("recommendations require an experience at the same company")
+var ErrInvalidModerationStatus = errors.New("status must be APPROVED or REJECTED")
+var ErrCannotApproveSelf = errors.New("you cannot approve yourself")
+var ErrVersionConflict = errors.New("profile was modified since it was loaded")
@@ -0,0 +1,97 @@
+package portfolio
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+const hoursPerYear = 24 * 365.25
+
+// SeniorityThresholds define os anos mínimos de experiência para cada nível.
+// Abaixo de MidLevel a sugestão é Junior.
+type SeniorityThresholds struct {
+	MidLevel  float64
+	Senior    float64
+	Lead      float64
+	Principal float64
+}
+
+// DefaultSeniorityThresholds é usado quando nada é configurado
+var DefaultSeniorityThresholds = SeniorityThresholds{
+	MidLevel:  2,
+	Senior:    5,
+	Lead:      8,
+	Principal: 12,
+}
+
+// Suggest retorna a senioridade compatível com os anos de experiência
+func (t SeniorityThresholds) Suggest(years float64) Seniority {
+	switch {
+	case years >= t.Principal:
+		return Principal
+	case years >= t.Lead:
+		return Lead
+	case years >= t.Senior:
+		return Senior
+	case years >= t.MidLevel:
+		return MidLevel
+	default:
+		return Junior
+	}
+}
+
+type period struct {
+	start time.Time
+	end   time.Time
+}
+
+// YearsOfExperience soma a duração das experiências unindo períodos sobrepostos
+// (dois empregos simultâneos não contam em dobro). EndDate nil significa que a
+// experiência continua até now. O resultado é arredondado para baixo em uma casa decimal.
+func (e Experiences) YearsOfExperience(now time.Time) float64 {
+	periods := make([]period, 0, len(e))
+	for _, exp := range e {
+		if exp.StartDate.IsZero() || exp.StartDate.After(now) {
+			continue
+		}
+		end := now
+		if exp.EndDate != nil && exp.EndDate.Before(now) {
+			end = *exp.EndDate
+		}
+		if end.Before(exp.StartDate) {
+			continue
+		}
+		periods = append(periods, period{start: exp.StartDate, end: end})
+	}
+	if len(periods) == 0 {
+		return 0
+	}
+
+	sort.Slice(periods, func(i, j int) bool {
+		return periods[i].start.Before(periods[j].start)
+	})
+
+	var total time.Duration
+	current := periods[0]
+	for _, p := range periods[1:] {
+		if !p.start.After(current.end) {
+			if p.end.After(current.end) {
+				current.end = p.end
+			}
+			continue
+		}
+		total += current.end.Sub(current.start)
+		current = p
+	}
+	total += current.end.Sub(current.start)
+
+	return math.Floor(total.Hours()/hoursPerYear*10) / 10
+}
+
+// withComputedExperience preenche os campos derivados das experiências
+func (p *Profile) withComputedExperience(thresholds SeniorityThresholds, now time.Time) *Profile {
+	p.ComputedYearsOfExp = p.Experiences.YearsOfExperience(now)
+	p.SuggestedSeniority = thresholds.Suggest(p.ComputedYearsOfExp)
+	return p
+}
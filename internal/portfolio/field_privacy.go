@@ -0,0 +1,123 @@
+package portfolio
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// FieldAudience define quem pode ver um grupo de campos sensíveis do perfil
+type FieldAudience string
+
+const (
+	// AudiencePublic qualquer pessoa que consiga abrir o perfil
+	AudiencePublic FieldAudience = "PUBLIC"
+	// AudienceAuthenticated apenas recrutadores logados
+	AudienceAuthenticated FieldAudience = "AUTHENTICATED"
+	// AudienceApproved apenas usuários aprovados pelo candidato
+	AudienceApproved FieldAudience = "APPROVED"
+	// AudiencePrivate apenas o próprio dono
+	AudiencePrivate FieldAudience = "PRIVATE"
+)
+
+// Nomes dos grupos de campos, usados em Profile.RedactedFields
+const (
+	FieldGroupSalary   = "salary"
+	FieldGroupContract = "contract"
+)
+
+// FieldPrivacy guarda a audiência de cada grupo de campos sensíveis.
+// Valores vazios são tratados como públicos.
+type FieldPrivacy struct {
+	// Salary cobre salaryExpectation e currency
+	Salary FieldAudience `json:"salary,omitempty"`
+	// Contract cobre contractType, location e remoteOnly
+	Contract FieldAudience `json:"contract,omitempty"`
+}
+
+// Viewer descreve quem está visualizando um perfil
+type Viewer struct {
+	UserID   string // vazio para visitantes anônimos
+	Approved bool   // aprovado pelo dono do perfil
+}
+
+// ApprovedViewer é um usuário aprovado para ver campos restritos de um perfil
+type ApprovedViewer struct {
+	UserID    string    `json:"userId"`
+	Email     string    `json:"email"`
+	FirstName string    `json:"firstName"`
+	LastName  string    `json:"lastName"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (a FieldAudience) IsValid() bool {
+	switch a {
+	case "", AudiencePublic, AudienceAuthenticated, AudienceApproved, AudiencePrivate:
+		return true
+	default:
+		return false
+	}
+}
+
+// allows verifica se o visualizador pode ver campos com esta audiência
+func (a FieldAudience) allows(p *Profile, v Viewer) bool {
+	if v.UserID != "" && v.UserID == p.UserID {
+		return true
+	}
+	switch a {
+	case AudienceAuthenticated:
+		return v.UserID != ""
+	case AudienceApproved:
+		return v.UserID != "" && v.Approved
+	case AudiencePrivate:
+		return false
+	default:
+		return true
+	}
+}
+
+// RequiresApproval indica se algum campo depende da lista de usuários aprovados
+func (f FieldPrivacy) RequiresApproval() bool {
+	return f.Salary == AudienceApproved || f.Contract == AudienceApproved
+}
+
+// Redact retorna uma cópia do perfil sem os campos que o visualizador não pode ver
+func (p *Profile) Redact(v Viewer) *Profile {
+	redacted := *p
+	redacted.RedactedFields = nil
+
+	if !p.FieldPrivacy.Salary.allows(p, v) {
+		redacted.SalaryExpectation = 0
+		redacted.Currency = ""
+		redacted.RedactedFields = append(redacted.RedactedFields, FieldGroupSalary)
+	}
+	if !p.FieldPrivacy.Contract.allows(p, v) {
+		redacted.ContractType = ""
+		redacted.Location = ""
+		redacted.RemoteOnly = false
+		redacted.RedactedFields = append(redacted.RedactedFields, FieldGroupContract)
+	}
+	return &redacted
+}
+
+// IsRedacted indica se o grupo de campos foi ocultado por Redact
+func (p *Profile) IsRedacted(group string) bool {
+	for _, f := range p.RedactedFields {
+		if f == group {
+			return true
+		}
+	}
+	return false
+}
+
+func (f FieldPrivacy) Value() (driver.Value, error) {
+	return json.Marshal(f)
+}
+func (f *FieldPrivacy) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, &f)
+}
@@ -0,0 +1,554 @@
+package portfolio
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// JSONResumeSchema é o schema publicado em https://jsonresume.org/schema
+const JSONResumeSchema = "https://raw.githubusercontent.com/jsonresume/resume-schema/v1.0.0/schema.json"
+
+// JSONResume cobre as seções do schema JSON Resume que têm equivalente no Profile.
+// Seções desconhecidas são ignoradas na importação.
+type JSONResume struct {
+	Schema    string              `json:"$schema,omitempty"`
+	Basics    JSONResumeBasics    `json:"basics"`
+	Work      []JSONResumeWork    `json:"work"`
+	Education []JSONResumeEduc    `json:"education"`
+	Projects  []JSONResumeProject `json:"projects"`
+	Skills    []JSONResumeSkill   `json:"skills"`
+	Meta      *JSONResumeMeta     `json:"meta,omitempty"`
+
+	Certificates []JSONResumeCertificate `json:"certificates"`
+	Languages    []JSONResumeLanguage    `json:"languages"`
+	Publications []JSONResumePublication `json:"publications"`
+}
+
+type JSONResumeBasics struct {
+	Name     string                    `json:"name,omitempty"`
+	Label    string                    `json:"label,omitempty"`
+	Image    string                    `json:"image,omitempty"`
+	Email    string                    `json:"email,omitempty"`
+	URL      string                    `json:"url,omitempty"`
+	Summary  string                    `json:"summary,omitempty"`
+	Profiles []JSONResumeSocialProfile `json:"profiles,omitempty"`
+}
+
+type JSONResumeSocialProfile struct {
+	Network  string `json:"network"`
+	Username string `json:"username,omitempty"`
+	URL      string `json:"url"`
+}
+
+type JSONResumeWork struct {
+	Name       string   `json:"name"`
+	Position   string   `json:"position"`
+	URL        string   `json:"url,omitempty"`
+	StartDate  string   `json:"startDate,omitempty"`
+	EndDate    string   `json:"endDate,omitempty"`
+	Summary    string   `json:"summary,omitempty"`
+	Highlights []string `json:"highlights,omitempty"`
+}
+
+type JSONResumeEduc struct {
+	Institution string `json:"institution"`
+	Area        string `json:"area,omitempty"`
+	StudyType   string `json:"studyType,omitempty"`
+	StartDate   string `json:"startDate,omitempty"`
+	EndDate     string `json:"endDate,omitempty"`
+}
+
+type JSONResumeProject struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	URL         string   `json:"url,omitempty"`
+	Keywords    []string `json:"keywords,omitempty"`
+	Highlights  []string `json:"highlights,omitempty"`
+}
+
+type JSONResumeSkill struct {
+	Name     string   `json:"name"`
+	Level    string   `json:"level,omitempty"`
+	Keywords []string `json:"keywords,omitempty"`
+}
+
+type JSONResumeCertificate struct {
+	Name   string `json:"name"`
+	Date   string `json:"date,omitempty"`
+	Issuer string `json:"issuer,omitempty"`
+	URL    string `json:"url,omitempty"`
+}
+
+type JSONResumeLanguage struct {
+	Language string `json:"language"`
+	Fluency  string `json:"fluency,omitempty"`
+}
+
+type JSONResumePublication struct {
+	Name        string `json:"name"`
+	Publisher   string `json:"publisher,omitempty"`
+	ReleaseDate string `json:"releaseDate,omitempty"`
+	URL         string `json:"url,omitempty"`
+	Summary     string `json:"summary,omitempty"`
+}
+
+type JSONResumeMeta struct {
+	Canonical    string `json:"canonical,omitempty"`
+	Version      string `json:"version,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// ImportChange descreve um campo que será (ou foi) alterado pela importação
+type ImportChange struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// ImportResult é a resposta da importação. Em DryRun nada é persistido e
+// Profile mostra como o rascunho ficaria.
+type ImportResult struct {
+	DryRun   bool           `json:"dryRun"`
+	Created  bool           `json:"created"`
+	Changes  []ImportChange `json:"changes"`
+	Warnings []string       `json:"warnings,omitempty"`
+	Profile  *Profile       `json:"profile"`
+}
+
+// jsonResumeDateLayouts são os formatos aceitos pelo schema (ISO 8601 parcial)
+var jsonResumeDateLayouts = []string{"2006-01-02", "2006-01", "2006"}
+
+func parseJSONResumeDate(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	for _, layout := range jsonResumeDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func formatJSONResumeDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+func formatJSONResumeDatePtr(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return formatJSONResumeDate(*t)
+}
+
+// ToJSONResume converte o perfil para o formato JSON Resume. O nome, e-mail e
+// foto vêm da conta do usuário, pois não fazem parte do Profile.
+func (p *Profile) ToJSONResume(owner JSONResumeBasics) JSONResume {
+	resume := JSONResume{
+		Schema:    JSONResumeSchema,
+		Basics:    owner,
+		Work:      make([]JSONResumeWork, 0, len(p.Experiences)),
+		Education: make([]JSONResumeEduc, 0, len(p.Educations)),
+		Projects:  make([]JSONResumeProject, 0, len(p.Projects)),
+		Skills:    make([]JSONResumeSkill, 0, len(p.Skills)),
+		Meta: &JSONResumeMeta{
+			Version:      "v1.0.0",
+			LastModified: p.UpdatedAt.UTC().Format(time.RFC3339),
+		},
+		Certificates: make([]JSONResumeCertificate, 0, len(p.Certifications)),
+		Languages:    make([]JSONResumeLanguage, 0, len(p.Languages)),
+		Publications: make([]JSONResumePublication, 0, len(p.Publications)),
+	}
+	resume.Basics.Label = p.Headline
+	resume.Basics.Summary = p.Bio
+	resume.Basics.URL = p.SocialLinks.Website
+	if p.SocialLinks.LinkedIn != "" {
+		resume.Basics.Profiles = append(resume.Basics.Profiles, JSONResumeSocialProfile{Network: "LinkedIn", URL: p.SocialLinks.LinkedIn})
+	}
+	if p.SocialLinks.GitHub != "" {
+		resume.Basics.Profiles = append(resume.Basics.Profiles, JSONResumeSocialProfile{Network: "GitHub", URL: p.SocialLinks.GitHub})
+	}
+
+	for _, exp := range p.Experiences {
+		resume.Work = append(resume.Work, JSONResumeWork{
+			Name:      exp.Company,
+			Position:  exp.Role,
+			StartDate: formatJSONResumeDate(exp.StartDate),
+			EndDate:   formatJSONResumeDatePtr(exp.EndDate),
+			Summary:   exp.Description,
+		})
+	}
+	for _, edu := range p.Educations {
+		resume.Education = append(resume.Education, JSONResumeEduc{
+			Institution: edu.Institution,
+			Area:        edu.Field,
+			StudyType:   edu.Degree,
+			StartDate:   formatJSONResumeDate(edu.StartDate),
+			EndDate:     formatJSONResumeDatePtr(edu.EndDate),
+		})
+	}
+	for _, proj := range p.Projects {
+		url := proj.LiveURL
+		if url == "" {
+			url = proj.RepoURL
+		}
+		resume.Projects = append(resume.Projects, JSONResumeProject{
+			Name:        proj.Name,
+			Description: proj.Description,
+			URL:         url,
+			Keywords:    proj.Tags,
+		})
+	}
+	for _, skill := range p.Skills {
+		resume.Skills = append(resume.Skills, JSONResumeSkill{Name: skill.Name, Level: jsonResumeSkillLevel(skill.Level)})
+	}
+	for _, cert := range p.Certifications {
+		resume.Certificates = append(resume.Certificates, JSONResumeCertificate{
+			Name:   cert.Name,
+			Date:   formatJSONResumeDate(cert.IssueDate),
+			Issuer: cert.Issuer,
+			URL:    cert.VerificationURL,
+		})
+	}
+	for _, lang := range p.Languages {
+		resume.Languages = append(resume.Languages, JSONResumeLanguage{
+			Language: lang.LanguageLabel(),
+			Fluency:  lang.Level.Label(),
+		})
+	}
+	for _, pub := range p.Publications {
+		resume.Publications = append(resume.Publications, JSONResumePublication{
+			Name:        pub.Title,
+			Publisher:   pub.Publisher,
+			ReleaseDate: formatJSONResumeDate(pub.Date),
+			URL:         pub.URL,
+			Summary:     pub.Description,
+		})
+	}
+	return resume
+}
+
+// ToPatch converte o currículo em um PATCH do perfil. Apenas as seções presentes
+// no documento são alteradas; tecnologias de experiências já existentes (mesma
+// empresa e cargo) são mantidas, pois o schema não tem campo equivalente.
+func (r JSONResume) ToPatch(current *Profile) PatchProfileDTO {
+	dto := PatchProfileDTO{}
+
+	if label := strings.TrimSpace(r.Basics.Label); label != "" {
+		dto.Headline = &label
+	}
+	if summary := strings.TrimSpace(r.Basics.Summary); summary != "" {
+		dto.Bio = &summary
+	}
+	if links, ok := r.socialLinks(current.SocialLinks); ok {
+		dto.SocialLinks = &links
+	}
+
+	if len(r.Work) > 0 {
+		experiences := make(Experiences, 0, len(r.Work))
+		for _, work := range r.Work {
+			exp := Experience{
+				Company:     strings.TrimSpace(work.Name),
+				Role:        strings.TrimSpace(work.Position),
+				Description: joinHighlights(work.Summary, work.Highlights),
+				TechStack:   []string{},
+			}
+			exp.StartDate, _ = parseJSONResumeDate(work.StartDate)
+			if end, ok := parseJSONResumeDate(work.EndDate); ok {
+				exp.EndDate = &end
+			}
+			for _, prev := range current.Experiences {
+				if strings.EqualFold(prev.Company, exp.Company) && strings.EqualFold(prev.Role, exp.Role) {
+					exp.TechStack = prev.TechStack
+					break
+				}
+			}
+			experiences = append(experiences, exp)
+		}
+		dto.Experiences = &experiences
+	}
+
+	if len(r.Education) > 0 {
+		educations := make(Educations, 0, len(r.Education))
+		for _, e := range r.Education {
+			edu := Education{
+				Institution: strings.TrimSpace(e.Institution),
+				Degree:      strings.TrimSpace(e.StudyType),
+				Field:       strings.TrimSpace(e.Area),
+			}
+			edu.StartDate, _ = parseJSONResumeDate(e.StartDate)
+			if end, ok := parseJSONResumeDate(e.EndDate); ok {
+				edu.EndDate = &end
+			}
+			educations = append(educations, edu)
+		}
+		dto.Educations = &educations
+	}
+
+	if len(r.Projects) > 0 {
+		projects := make(Projects, 0, len(r.Projects))
+		for _, rp := range r.Projects {
+			proj := Project{
+				Name:        strings.TrimSpace(rp.Name),
+				Description: joinHighlights(rp.Description, rp.Highlights),
+				Tags:        rp.Keywords,
+				Provider:    &localProjectProvider,
+			}
+			if proj.Tags == nil {
+				proj.Tags = []string{}
+			}
+			if isRepositoryURL(rp.URL) {
+				proj.RepoURL = rp.URL
+			} else {
+				proj.LiveURL = rp.URL
+			}
+			// A galeria não existe no JSON Resume: mantém a do projeto de mesmo nome
+			for _, prev := range current.Projects {
+				if strings.EqualFold(prev.Name, proj.Name) {
+					proj.Media = prev.Media
+					break
+				}
+			}
+			projects = append(projects, proj)
+		}
+		dto.Projects = &projects
+	}
+
+	if len(r.Skills) > 0 {
+		skills := make(Skills, 0, len(r.Skills))
+		seen := make(map[string]struct{})
+		for _, s := range r.Skills {
+			name := strings.TrimSpace(s.Name)
+			if name == "" {
+				continue
+			}
+			key := strings.ToLower(name)
+			if _, dup := seen[key]; dup {
+				continue
+			}
+			seen[key] = struct{}{}
+			skill := Skill{Name: name}
+			// Anos e último uso não existem no schema: mantém os da habilidade de mesmo nome
+			for _, prev := range current.Skills {
+				if strings.EqualFold(prev.Name, name) {
+					skill = prev
+					skill.Name = name
+					break
+				}
+			}
+			if level, ok := ParseSkillLevel(s.Level); ok {
+				skill.Level = level
+			}
+			skills = append(skills, skill)
+		}
+		dto.Skills = &skills
+	}
+
+	if len(r.Certificates) > 0 {
+		certifications := make(Certifications, 0, len(r.Certificates))
+		for _, c := range r.Certificates {
+			cert := Certification{
+				Name:            strings.TrimSpace(c.Name),
+				Issuer:          strings.TrimSpace(c.Issuer),
+				VerificationURL: strings.TrimSpace(c.URL),
+			}
+			cert.IssueDate, _ = parseJSONResumeDate(c.Date)
+			// ID da credencial e validade não existem no schema: mantém os da certificação de mesmo nome
+			for _, prev := range current.Certifications {
+				if strings.EqualFold(prev.Name, cert.Name) {
+					cert.CredentialID = prev.CredentialID
+					cert.ExpiryDate = prev.ExpiryDate
+					break
+				}
+			}
+			certifications = append(certifications, cert)
+		}
+		dto.Certifications = &certifications
+	}
+
+	if len(r.Languages) > 0 {
+		// Idiomas ou níveis não reconhecidos são ignorados
+		languages := make(Languages, 0, len(r.Languages))
+		seen := make(map[string]bool)
+		for _, l := range r.Languages {
+			code, ok := LookupLanguage(l.Language)
+			if !ok || seen[code] {
+				continue
+			}
+			level, ok := ParseLanguageLevel(l.Fluency)
+			if !ok {
+				continue
+			}
+			seen[code] = true
+			languages = append(languages, LanguageSkill{Language: code, Level: level})
+		}
+		dto.Languages = &languages
+	}
+
+	if len(r.Publications) > 0 {
+		publications := make(Publications, 0, len(r.Publications))
+		for _, rp := range r.Publications {
+			pub := Publication{
+				Kind:        PublicationArticle,
+				Title:       strings.TrimSpace(rp.Name),
+				Publisher:   strings.TrimSpace(rp.Publisher),
+				URL:         strings.TrimSpace(rp.URL),
+				Description: strings.TrimSpace(rp.Summary),
+			}
+			pub.Date, _ = parseJSONResumeDate(rp.ReleaseDate)
+			// O schema não distingue artigos de palestras: mantém o tipo da publicação de mesmo título
+			for _, prev := range current.Publications {
+				if strings.EqualFold(prev.Title, pub.Title) {
+					pub.Kind = prev.Kind
+					break
+				}
+			}
+			publications = append(publications, pub)
+		}
+		dto.Publications = &publications
+	}
+
+	return dto
+}
+
+// socialLinks extrai LinkedIn, GitHub e site pessoal de basics
+func (r JSONResume) socialLinks(current SocialLinks) (SocialLinks, bool) {
+	links := current
+	changed := false
+	if r.Basics.URL != "" {
+		links.Website = r.Basics.URL
+		changed = true
+	}
+	for _, sp := range r.Basics.Profiles {
+		switch strings.ToLower(sp.Network) {
+		case "linkedin":
+			links.LinkedIn = sp.URL
+			changed = true
+		case "github":
+			links.GitHub = sp.URL
+			changed = true
+		}
+	}
+	return links, changed
+}
+
+// joinHighlights acrescenta os destaques como uma lista ao final da descrição
+func joinHighlights(summary string, highlights []string) string {
+	lines := []string{}
+	if s := strings.TrimSpace(summary); s != "" {
+		lines = append(lines, s)
+	}
+	for _, h := range highlights {
+		if h = strings.TrimSpace(h); h != "" {
+			lines = append(lines, "- "+h)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func isRepositoryURL(url string) bool {
+	lower := strings.ToLower(url)
+	return strings.Contains(lower, "github.com/") || strings.Contains(lower, "gitlab.com/") || strings.Contains(lower, "bitbucket.org/")
+}
+
+// diffImport lista os campos alterados entre o perfil atual e o resultado da importação
+func diffImport(before, after *Profile) []ImportChange {
+	fields := []struct {
+		name          string
+		before, after interface{}
+	}{
+		{"headline", before.Headline, after.Headline},
+		{"bio", before.Bio, after.Bio},
+		{"socialLinks", before.SocialLinks, after.SocialLinks},
+		{"skills", before.Skills, after.Skills},
+		{"experiences", before.Experiences, after.Experiences},
+		{"projects", before.Projects, after.Projects},
+		{"educations", before.Educations, after.Educations},
+		{"certifications", before.Certifications, after.Certifications},
+		{"languages", before.Languages, after.Languages},
+		{"publications", before.Publications, after.Publications},
+	}
+
+	changes := []ImportChange{}
+	for _, f := range fields {
+		// Compara a forma serializada para ignorar diferenças de fuso nas datas
+		b, _ := json.Marshal(f.before)
+		a, _ := json.Marshal(f.after)
+		if !bytes.Equal(b, a) {
+			changes = append(changes, ImportChange{Field: f.name, Before: f.before, After: f.after})
+		}
+	}
+	return changes
+}
+
+// resumePaths traduz os caminhos do Profile para os do documento JSON Resume,
+// para que os erros de validação apontem para o campo enviado pelo usuário
+var resumePaths = []struct{ profile, resume string }{
+	{"/headline", "/basics/label"},
+	{"/bio", "/basics/summary"},
+	{"/socialLinks/website", "/basics/url"},
+	{"/socialLinks", "/basics/profiles"},
+	{"/experiences", "/work"},
+	{"/educations", "/education"},
+	{"/certifications", "/certificates"},
+}
+
+var resumeItemFields = map[string]string{
+	"company":     "name",
+	"role":        "position",
+	"description": "summary",
+	"degree":      "studyType",
+	"field":       "area",
+	"repoUrl":     "url",
+	"liveUrl":     "url",
+	// Certificações, idiomas e publicações
+	"issueDate":       "date",
+	"verificationUrl": "url",
+	"level":           "fluency",
+	"title":           "name",
+	"date":            "releaseDate",
+}
+
+// toResumeErrors reescreve os caminhos de ValidationErrors; outros erros são devolvidos sem alteração
+func toResumeErrors(err error) error {
+	verrs, ok := err.(ValidationErrors)
+	if !ok {
+		return err
+	}
+	mapped := make(ValidationErrors, len(verrs))
+	for i, fe := range verrs {
+		path := fe.Path
+		for _, rp := range resumePaths {
+			if rest, found := strings.CutPrefix(path, rp.profile); found {
+				path = rp.resume + rest
+				break
+			}
+		}
+		if idx := strings.LastIndex(path, "/"); idx >= 0 {
+			if name, found := resumeItemFields[path[idx+1:]]; found && strings.Count(path, "/") > 2 {
+				path = path[:idx+1] + name
+			}
+		}
+		mapped[i] = FieldError{Path: path, Message: fe.Message}
+	}
+	return mapped
+}
+
+// jsonResumeSkillLevel descreve o nível da habilidade em inglês, como nos exemplos do schema
+func jsonResumeSkillLevel(level SkillLevel) string {
+	switch level {
+	case SkillBeginner:
+		return "Beginner"
+	case SkillIntermediate:
+		return "Intermediate"
+	case SkillAdvanced:
+		return "Advanced"
+	case SkillExpert:
+		return "Expert"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,195 @@
+package portfolio
+
+import (
+	"sort"
+	"strings"
+)
+
+// LanguageLevel é o nível de proficiência em um idioma falado, na escala do
+// Quadro Europeu Comum de Referência (CEFR), mais o nível nativo
+type LanguageLevel string
+
+const (
+	LevelA1     LanguageLevel = "A1"
+	LevelA2     LanguageLevel = "A2"
+	LevelB1     LanguageLevel = "B1"
+	LevelB2     LanguageLevel = "B2"
+	LevelC1     LanguageLevel = "C1"
+	LevelC2     LanguageLevel = "C2"
+	LevelNative LanguageLevel = "NATIVE"
+)
+
+// LanguageLevels lista os níveis do menor para o maior
+var LanguageLevels = []LanguageLevel{LevelA1, LevelA2, LevelB1, LevelB2, LevelC1, LevelC2, LevelNative}
+
+func (l LanguageLevel) Int() int {
+	for i, level := range LanguageLevels {
+		if level == l {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func (l LanguageLevel) IsValid() bool {
+	return l.Int() != 0
+}
+
+// Label retorna a descrição do nível exibida no portfólio
+func (l LanguageLevel) Label() string {
+	switch l {
+	case LevelA1:
+		return "Básico (A1)"
+	case LevelA2:
+		return "Básico (A2)"
+	case LevelB1:
+		return "Intermediário (B1)"
+	case LevelB2:
+		return "Intermediário (B2)"
+	case LevelC1:
+		return "Avançado (C1)"
+	case LevelC2:
+		return "Proficiente (C2)"
+	case LevelNative:
+		return "Nativo"
+	default:
+		return string(l)
+	}
+}
+
+// ParseLanguageLevel reconhece o nível em textos livres como "C1", "Fluente" ou
+// "Native speaker" (usado nas importações)
+func ParseLanguageLevel(text string) (LanguageLevel, bool) {
+	text = strings.ToUpper(strings.TrimSpace(text))
+	for _, level := range LanguageLevels {
+		if strings.Contains(text, string(level)) {
+			return level, true
+		}
+	}
+	switch {
+	case strings.Contains(text, "NATIV"), strings.Contains(text, "BILINGU"):
+		return LevelNative, true
+	case strings.Contains(text, "FLUEN"), strings.Contains(text, "FULL PROFESSIONAL"):
+		return LevelC1, true
+	case strings.Contains(text, "PROFESSIONAL"), strings.Contains(text, "AVANÇ"), strings.Contains(text, "ADVANCED"):
+		return LevelB2, true
+	case strings.Contains(text, "INTERMEDI"), strings.Contains(text, "LIMITED"):
+		return LevelB1, true
+	case strings.Contains(text, "BÁSIC"), strings.Contains(text, "BASIC"), strings.Contains(text, "ELEMENTARY"):
+		return LevelA2, true
+	}
+	return "", false
+}
+
+// spokenLanguage descreve um idioma aceito em LanguageSkill.Language
+type spokenLanguage struct {
+	// Nome exibido no portfólio
+	label string
+	// Nome em inglês, usado para reconhecer o idioma nas importações
+	english string
+}
+
+// spokenLanguages são os idiomas aceitos, indexados pelo código ISO 639-1
+var spokenLanguages = map[string]spokenLanguage{
+	"pt": {"Português", "Portuguese"},
+	"en": {"Inglês", "English"},
+	"es": {"Espanhol", "Spanish"},
+	"fr": {"Francês", "French"},
+	"de": {"Alemão", "German"},
+	"it": {"Italiano", "Italian"},
+	"nl": {"Holandês", "Dutch"},
+	"sv": {"Sueco", "Swedish"},
+	"pl": {"Polonês", "Polish"},
+	"ru": {"Russo", "Russian"},
+	"uk": {"Ucraniano", "Ukrainian"},
+	"tr": {"Turco", "Turkish"},
+	"ar": {"Árabe", "Arabic"},
+	"he": {"Hebraico", "Hebrew"},
+	"hi": {"Hindi", "Hindi"},
+	"zh": {"Chinês", "Chinese"},
+	"ja": {"Japonês", "Japanese"},
+	"ko": {"Coreano", "Korean"},
+	"eo": {"Esperanto", "Esperanto"},
+}
+
+// LanguageOption é um idioma exibido nos seletores do editor e da busca
+type LanguageOption struct {
+	Code  string
+	Label string
+}
+
+// SpokenLanguageOptions lista os idiomas aceitos ordenados pelo nome
+func SpokenLanguageOptions() []LanguageOption {
+	options := make([]LanguageOption, 0, len(spokenLanguages))
+	for code, lang := range spokenLanguages {
+		options = append(options, LanguageOption{Code: code, Label: lang.label})
+	}
+	sort.Slice(options, func(i, j int) bool { return options[i].Label < options[j].Label })
+	return options
+}
+
+// IsKnownLanguage indica se o código é um dos idiomas aceitos
+func IsKnownLanguage(code string) bool {
+	_, ok := spokenLanguages[code]
+	return ok
+}
+
+// LookupLanguage encontra o código do idioma pelo código, nome em português ou em inglês
+func LookupLanguage(name string) (string, bool) {
+	name = strings.TrimSpace(name)
+	if _, ok := spokenLanguages[strings.ToLower(name)]; ok {
+		return strings.ToLower(name), true
+	}
+	for code, lang := range spokenLanguages {
+		if strings.EqualFold(lang.label, name) || strings.EqualFold(lang.english, name) {
+			return code, true
+		}
+	}
+	return "", false
+}
+
+// LanguageLabel retorna o nome do idioma exibido no portfólio
+func (l LanguageSkill) LanguageLabel() string {
+	if lang, ok := spokenLanguages[l.Language]; ok {
+		return lang.label
+	}
+	return strings.ToUpper(l.Language)
+}
+
+// SearchTerms gera os valores indexados para o filtro de idiomas. Cada idioma
+// entra com todos os níveis até o declarado ("en", "en:A1", ..., "en:C1"), de
+// modo que o filtro languages = 'en:B2' significa "inglês B2 ou superior".
+func (ls Languages) SearchTerms() []string {
+	terms := make([]string, 0, len(ls)*len(LanguageLevels))
+	for _, l := range ls {
+		if !IsKnownLanguage(l.Language) {
+			continue
+		}
+		terms = append(terms, l.Language)
+		for _, level := range LanguageLevels[:l.Level.Int()] {
+			terms = append(terms, l.Language+":"+string(level))
+		}
+	}
+	return terms
+}
+
+// ParseLanguageFilter normaliza um filtro de idioma da busca ("en", "EN:c1",
+// "en-C1") para o formato indexado por SearchTerms
+func ParseLanguageFilter(filter string) (string, bool) {
+	code, level, hasLevel := strings.Cut(strings.TrimSpace(filter), ":")
+	if !hasLevel {
+		code, level, hasLevel = strings.Cut(code, "-")
+	}
+	code = strings.ToLower(strings.TrimSpace(code))
+	if !IsKnownLanguage(code) {
+		return "", false
+	}
+	if !hasLevel || strings.TrimSpace(level) == "" {
+		return code, true
+	}
+	lvl := LanguageLevel(strings.ToUpper(strings.TrimSpace(level)))
+	if !lvl.IsValid() {
+		return "", false
+	}
+	return code + ":" + string(lvl), true
+}
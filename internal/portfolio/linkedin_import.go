@@ -0,0 +1,260 @@
+package portfolio
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// MaxLinkedInExportSize limita o tamanho do ZIP enviado (o export completo do
+// LinkedIn inclui mensagens e conexões, por isso o limite é generoso)
+const MaxLinkedInExportSize = 50 << 20
+
+// maxLinkedInCSVSize limita o tamanho descompactado de cada CSV lido do ZIP,
+// para que um arquivo muito comprimido não esgote a memória do servidor
+const maxLinkedInCSVSize = 5 << 20
+
+// Arquivos do export do LinkedIn ("Obter uma cópia dos seus dados") usados na importação
+const (
+	linkedInProfileFile   = "profile.csv"
+	linkedInPositionsFile = "positions.csv"
+	linkedInEducationFile = "education.csv"
+	linkedInSkillsFile    = "skills.csv"
+)
+
+// linkedInDateLayouts cobre os formatos usados nos CSVs ("Mar 2019", "2019")
+var linkedInDateLayouts = []string{"Jan 2006", "January 2006", "Jan 02, 2006", "2006-01-02", "2006"}
+
+var urlPattern = regexp.MustCompile(`https?://[^\s,\]]+`)
+
+// ParseLinkedInExport lê o ZIP exportado pelo LinkedIn e monta um SaveProfileInput.
+// Linhas incompletas são ignoradas e descritas em warnings.
+func ParseLinkedInExport(r io.ReaderAt, size int64) (SaveProfileInput, []string, error) {
+	input := SaveProfileInput{
+		Skills:      Skills{},
+		Experiences: Experiences{},
+		Educations:  Educations{},
+	}
+	warnings := []string{}
+
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return input, nil, ErrInvalidImportFile
+	}
+
+	files := make(map[string]*zip.File)
+	for _, f := range archive.File {
+		files[strings.ToLower(path.Base(f.Name))] = f
+	}
+	if files[linkedInProfileFile] == nil && files[linkedInPositionsFile] == nil &&
+		files[linkedInEducationFile] == nil && files[linkedInSkillsFile] == nil {
+		return input, nil, ErrInvalidImportFile
+	}
+
+	if rows, err := readLinkedInCSV(files[linkedInProfileFile]); err != nil {
+		return input, nil, err
+	} else if len(rows) > 0 {
+		input.Headline = rows[0]["headline"]
+		input.Bio = rows[0]["summary"]
+		if url := urlPattern.FindString(rows[0]["websites"]); url != "" {
+			input.SocialLinks.Website = url
+		}
+	}
+
+	rows, err := readLinkedInCSV(files[linkedInPositionsFile])
+	if err != nil {
+		return input, nil, err
+	}
+	for i, row := range rows {
+		exp := Experience{
+			Company:     row["company name"],
+			Role:        row["title"],
+			Description: row["description"],
+			TechStack:   []string{},
+		}
+		start, ok := parseLinkedInDate(row["started on"])
+		if exp.Company == "" || exp.Role == "" || !ok {
+			warnings = append(warnings, fmt.Sprintf("Positions.csv registro %d ignorado: empresa, cargo ou data de início ausente", i+1))
+			continue
+		}
+		exp.StartDate = start
+		if end, ok := parseLinkedInDate(row["finished on"]); ok {
+			exp.EndDate = &end
+		}
+		input.Experiences = append(input.Experiences, exp)
+	}
+
+	rows, err = readLinkedInCSV(files[linkedInEducationFile])
+	if err != nil {
+		return input, nil, err
+	}
+	for i, row := range rows {
+		edu := Education{
+			Institution: row["school name"],
+			Degree:      row["degree name"],
+		}
+		start, ok := parseLinkedInDate(row["start date"])
+		if edu.Institution == "" || !ok {
+			warnings = append(warnings, fmt.Sprintf("Education.csv registro %d ignorado: instituição ou data de início ausente", i+1))
+			continue
+		}
+		edu.StartDate = start
+		if end, ok := parseLinkedInDate(row["end date"]); ok {
+			edu.EndDate = &end
+		}
+		input.Educations = append(input.Educations, edu)
+	}
+
+	rows, err = readLinkedInCSV(files[linkedInSkillsFile])
+	if err != nil {
+		return input, nil, err
+	}
+	for _, row := range rows {
+		if name := row["name"]; name != "" {
+			input.Skills = append(input.Skills, Skill{Name: name})
+		}
+	}
+
+	return input, warnings, nil
+}
+
+// readLinkedInCSV devolve as linhas do arquivo como mapas indexados pelo
+// cabeçalho em minúsculas. Arquivo ausente resulta em nenhuma linha.
+func readLinkedInCSV(f *zip.File) ([]map[string]string, error) {
+	if f == nil {
+		return nil, nil
+	}
+	if f.UncompressedSize64 > maxLinkedInCSVSize {
+		return nil, fmt.Errorf("%w: %s is too large", ErrInvalidImportFile, f.Name)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, ErrInvalidImportFile
+	}
+	defer rc.Close()
+
+	// O tamanho no cabeçalho do ZIP pode ser falso: lê no máximo um byte além do limite
+	data, err := io.ReadAll(io.LimitReader(rc, maxLinkedInCSVSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidImportFile, f.Name, err)
+	}
+	if len(data) > maxLinkedInCSVSize {
+		return nil, fmt.Errorf("%w: %s is too large", ErrInvalidImportFile, f.Name)
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidImportFile, f.Name, err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	header := make([]string, len(records[0]))
+	for i, h := range records[0] {
+		header[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+	}
+
+	rows := make([]map[string]string, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make(map[string]string, len(header))
+		for i, value := range record {
+			if i < len(header) {
+				row[header[i]] = strings.TrimSpace(value)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func parseLinkedInDate(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	for _, layout := range linkedInDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// MergeInto combina os dados importados com o perfil atual sem apagar nada:
+// título e bio só são preenchidos se estiverem vazios, experiências e formações
+// novas são acrescentadas ao final (a ordem existente é mantida porque as
+// traduções são alinhadas por posição) e as habilidades são unidas.
+func (in SaveProfileInput) MergeInto(current *Profile) PatchProfileDTO {
+	dto := PatchProfileDTO{}
+
+	if current.Headline == "" && in.Headline != "" {
+		dto.Headline = &in.Headline
+	}
+	if current.Bio == "" && in.Bio != "" {
+		dto.Bio = &in.Bio
+	}
+	if current.SocialLinks.Website == "" && in.SocialLinks.Website != "" {
+		links := current.SocialLinks
+		links.Website = in.SocialLinks.Website
+		dto.SocialLinks = &links
+	}
+
+	experiences := append(Experiences{}, current.Experiences...)
+	for _, exp := range in.Experiences {
+		if !containsExperience(experiences, exp) {
+			experiences = append(experiences, exp)
+		}
+	}
+	if len(experiences) != len(current.Experiences) {
+		dto.Experiences = &experiences
+	}
+
+	educations := append(Educations{}, current.Educations...)
+	for _, edu := range in.Educations {
+		if !containsEducation(educations, edu) {
+			educations = append(educations, edu)
+		}
+	}
+	if len(educations) != len(current.Educations) {
+		dto.Educations = &educations
+	}
+
+	skills := append(Skills{}, current.Skills...)
+	for _, skill := range in.Skills {
+		if !skills.Contains(skill.Name) {
+			skills = append(skills, skill)
+		}
+	}
+	if len(skills) != len(current.Skills) {
+		dto.Skills = &skills
+	}
+
+	return dto
+}
+
+// containsExperience compara empresa, cargo e mês de início
+func containsExperience(list Experiences, exp Experience) bool {
+	for _, e := range list {
+		if strings.EqualFold(e.Company, exp.Company) && strings.EqualFold(e.Role, exp.Role) &&
+			e.StartDate.Year() == exp.StartDate.Year() && e.StartDate.Month() == exp.StartDate.Month() {
+			return true
+		}
+	}
+	return false
+}
+
+func containsEducation(list Educations, edu Education) bool {
+	for _, e := range list {
+		if strings.EqualFold(e.Institution, edu.Institution) && strings.EqualFold(e.Degree, edu.Degree) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,223 @@
+package portfolio
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Locale identifica o idioma de um conteúdo do perfil (tag BCP 47)
+type Locale string
+
+const (
+	LocalePtBR Locale = "pt-BR"
+	LocaleEn   Locale = "en"
+	LocaleEs   Locale = "es"
+)
+
+// DefaultLocale é o idioma assumido para perfis que não informaram um
+const DefaultLocale = LocalePtBR
+
+// SupportedLocales lista os idiomas aceitos, na ordem exibida para o usuário
+var SupportedLocales = []Locale{LocalePtBR, LocaleEn, LocaleEs}
+
+// localeLabels são os nomes exibidos no seletor de idioma
+var localeLabels = map[Locale]string{
+	LocalePtBR: "Português",
+	LocaleEn:   "English",
+	LocaleEs:   "Español",
+}
+
+func (l Locale) IsValid() bool {
+	_, ok := localeLabels[l]
+	return ok
+}
+
+// Label retorna o nome do idioma no próprio idioma (ex: "English")
+func (l Locale) Label() string {
+	return localeLabels[l]
+}
+
+// ParseLocale converte uma tag informada pelo usuário ou pelo navegador
+// ("en-US", "pt_br", "es") para um idioma suportado
+func ParseLocale(tag string) (Locale, bool) {
+	tag = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+	if tag == "" {
+		return "", false
+	}
+	base, _, _ := strings.Cut(tag, "-")
+	switch base {
+	case "pt":
+		return LocalePtBR, true
+	case "en":
+		return LocaleEn, true
+	case "es":
+		return LocaleEs, true
+	}
+	return "", false
+}
+
+// NegotiateLocale escolhe o idioma de exibição entre os disponíveis no perfil.
+// A ordem de preferência é: ?lang= explícito, Accept-Language (por peso q) e,
+// por fim, o idioma padrão do perfil.
+func NegotiateLocale(requested string, acceptLanguage string, available []Locale, fallback Locale) Locale {
+	has := func(l Locale) bool {
+		for _, a := range available {
+			if a == l {
+				return true
+			}
+		}
+		return false
+	}
+
+	if l, ok := ParseLocale(requested); ok && has(l) {
+		return l
+	}
+	for _, tag := range parseAcceptLanguage(acceptLanguage) {
+		if l, ok := ParseLocale(tag); ok && has(l) {
+			return l
+		}
+	}
+	return fallback
+}
+
+// parseAcceptLanguage retorna as tags do cabeçalho ordenadas por peso (q), descartando q=0
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+		tags = append(tags, weighted{tag: tag, q: q})
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].q > tags[j].q })
+
+	result := make([]string, len(tags))
+	for i, t := range tags {
+		result[i] = t.tag
+	}
+	return result
+}
+
+// Translation guarda a versão de um idioma para os campos de texto do perfil.
+// Campos vazios usam o texto do idioma padrão.
+type Translation struct {
+	Headline string `json:"headline,omitempty"`
+	Bio      string `json:"bio,omitempty"`
+	// Descrições por posição, alinhadas com Profile.Experiences e Profile.Projects
+	Experiences []string `json:"experiences,omitempty"`
+	Projects    []string `json:"projects,omitempty"`
+}
+
+// Translations mapeia cada idioma adicional para sua tradução
+type Translations map[Locale]Translation
+
+func (t Translations) Value() (driver.Value, error) {
+	if t == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(t)
+}
+func (t *Translations) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, &t)
+}
+
+// ContentLocale retorna o idioma em que os campos principais foram escritos
+func (p *Profile) ContentLocale() Locale {
+	if p.DefaultLocale == "" {
+		return DefaultLocale
+	}
+	return p.DefaultLocale
+}
+
+// AvailableLocales retorna o idioma padrão seguido dos idiomas com tradução
+func (p *Profile) AvailableLocales() []Locale {
+	locales := []Locale{p.ContentLocale()}
+	for _, l := range SupportedLocales {
+		if l == p.ContentLocale() {
+			continue
+		}
+		if _, ok := p.Translations[l]; ok {
+			locales = append(locales, l)
+		}
+	}
+	return locales
+}
+
+// descriptions junta as descrições de experiências e projetos (usado na indexação)
+func (p *Profile) descriptions() []string {
+	descs := make([]string, 0, len(p.Experiences)+len(p.Projects))
+	for _, exp := range p.Experiences {
+		if exp.Description != "" {
+			descs = append(descs, exp.Description)
+		}
+	}
+	for _, proj := range p.Projects {
+		if proj.Description != "" {
+			descs = append(descs, proj.Description)
+		}
+	}
+	return descs
+}
+
+// Localize retorna uma cópia do perfil com os textos no idioma pedido.
+// Idiomas sem tradução (ou campos traduzidos vazios) mantêm o texto padrão.
+func (p *Profile) Localize(locale Locale) *Profile {
+	localized := *p
+	localized.Locale = p.ContentLocale()
+
+	t, ok := p.Translations[locale]
+	if !ok || locale == p.ContentLocale() {
+		return &localized
+	}
+	localized.Locale = locale
+
+	if t.Headline != "" {
+		localized.Headline = t.Headline
+	}
+	if t.Bio != "" {
+		localized.Bio = t.Bio
+	}
+	if len(t.Experiences) > 0 {
+		localized.Experiences = make(Experiences, len(p.Experiences))
+		copy(localized.Experiences, p.Experiences)
+		for i := range localized.Experiences {
+			if i < len(t.Experiences) && t.Experiences[i] != "" {
+				localized.Experiences[i].Description = t.Experiences[i]
+			}
+		}
+	}
+	if len(t.Projects) > 0 {
+		localized.Projects = make(Projects, len(p.Projects))
+		copy(localized.Projects, p.Projects)
+		for i := range localized.Projects {
+			if i < len(t.Projects) && t.Projects[i] != "" {
+				localized.Projects[i].Description = t.Projects[i]
+			}
+		}
+	}
+	return &localized
+}
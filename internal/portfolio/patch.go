@@ -0,0 +1,444 @@
+package portfolio
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Tipos de mídia aceitos em PATCH além do PatchProfileDTO (application/json)
+const (
+	MergePatchContentType = "application/merge-patch+json"
+	JSONPatchContentType  = "application/json-patch+json"
+)
+
+var ErrInvalidPatch = errors.New("patch cannot be applied to the profile")
+
+// DocumentPatch altera o documento do perfil: o mesmo JSON de PatchProfileDTO,
+// com todos os campos preenchidos
+type DocumentPatch interface {
+	apply(doc interface{}) (interface{}, error)
+}
+
+// MergePatch é um JSON Merge Patch (RFC 7396): objetos são mesclados, null
+// remove o membro e qualquer outro valor (inclusive arrays) substitui o atual
+type MergePatch json.RawMessage
+
+func (m MergePatch) apply(doc interface{}) (interface{}, error) {
+	var patch interface{}
+	if err := json.Unmarshal(m, &patch); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	if _, ok := patch.(map[string]interface{}); !ok {
+		return nil, fmt.Errorf("%w: merge patch must be a JSON object", ErrInvalidPatch)
+	}
+	return mergePatch(doc, patch), nil
+}
+
+func mergePatch(target interface{}, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]interface{})
+	if !ok {
+		t = map[string]interface{}{}
+	}
+	for key, value := range p {
+		if value == nil {
+			delete(t, key)
+			continue
+		}
+		t[key] = mergePatch(t[key], value)
+	}
+	return t
+}
+
+// PatchOperation é uma operação de JSON Patch (RFC 6902)
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// JSONPatch é uma lista de operações aplicadas em ordem; se alguma falhar,
+// nenhuma alteração é salva
+type JSONPatch []PatchOperation
+
+func (p JSONPatch) apply(doc interface{}) (interface{}, error) {
+	var err error
+	for i, op := range p {
+		doc, err = op.apply(doc)
+		if err != nil {
+			return nil, fmt.Errorf("%w: operation %d (%s %s): %v", ErrInvalidPatch, i, op.Op, op.Path, err)
+		}
+	}
+	return doc, nil
+}
+
+func (op PatchOperation) value() (interface{}, error) {
+	if len(op.Value) == 0 {
+		return nil, errors.New(`missing "value"`)
+	}
+	var v interface{}
+	err := json.Unmarshal(op.Value, &v)
+	return v, err
+}
+
+func (op PatchOperation) apply(doc interface{}) (interface{}, error) {
+	switch op.Op {
+	case "add":
+		v, err := op.value()
+		if err != nil {
+			return nil, err
+		}
+		return addPointer(doc, op.Path, v)
+	case "remove":
+		doc, _, err := removePointer(doc, op.Path)
+		return doc, err
+	case "replace":
+		v, err := op.value()
+		if err != nil {
+			return nil, err
+		}
+		if op.Path == "" {
+			return v, nil
+		}
+		doc, _, err = removePointer(doc, op.Path)
+		if err != nil {
+			return nil, err
+		}
+		return addPointer(doc, op.Path, v)
+	case "move":
+		if op.Path == op.From {
+			_, err := getPointer(doc, op.From)
+			return doc, err
+		}
+		if strings.HasPrefix(op.Path, op.From+"/") {
+			return nil, errors.New(`"path" cannot be inside "from"`)
+		}
+		doc, v, err := removePointer(doc, op.From)
+		if err != nil {
+			return nil, err
+		}
+		return addPointer(doc, op.Path, v)
+	case "copy":
+		v, err := getPointer(doc, op.From)
+		if err != nil {
+			return nil, err
+		}
+		return addPointer(doc, op.Path, deepCopy(v))
+	case "test":
+		expected, err := op.value()
+		if err != nil {
+			return nil, err
+		}
+		actual, err := getPointer(doc, op.Path)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			return nil, errors.New("test failed")
+		}
+		return doc, nil
+	default:
+		return nil, fmt.Errorf("unknown op %q", op.Op)
+	}
+}
+
+// parsePointer divide um JSON Pointer (RFC 6901) em tokens já decodificados
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer %q", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(t, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// arrayIndex converte o token em índice; "-" (fim do array) só vale em add
+func arrayIndex(token string, length int, allowEnd bool) (int, error) {
+	if token == "-" && allowEnd {
+		return length, nil
+	}
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	max := length - 1
+	if allowEnd {
+		max = length
+	}
+	if i > max {
+		return 0, fmt.Errorf("array index %d out of range", i)
+	}
+	return i, nil
+}
+
+func getPointer(doc interface{}, pointer string) (interface{}, error) {
+	tokens, err := parsePointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	current := doc
+	for _, token := range tokens {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			v, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("path %q not found", pointer)
+			}
+			current = v
+		case []interface{}:
+			i, err := arrayIndex(token, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			current = node[i]
+		default:
+			return nil, fmt.Errorf("path %q not found", pointer)
+		}
+	}
+	return current, nil
+}
+
+// addPointer insere value em pointer e retorna o documento (que muda quando
+// um array é estendido, pois o slice é realocado)
+func addPointer(doc interface{}, pointer string, value interface{}) (interface{}, error) {
+	tokens, err := parsePointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	return setIn(doc, tokens, func(parent interface{}, last string) (interface{}, error) {
+		switch node := parent.(type) {
+		case map[string]interface{}:
+			node[last] = value
+			return node, nil
+		case []interface{}:
+			i, err := arrayIndex(last, len(node), true)
+			if err != nil {
+				return nil, err
+			}
+			node = append(node, nil)
+			copy(node[i+1:], node[i:])
+			node[i] = value
+			return node, nil
+		default:
+			return nil, fmt.Errorf("path %q not found", pointer)
+		}
+	})
+}
+
+// removePointer remove o valor em pointer e o retorna junto com o documento
+func removePointer(doc interface{}, pointer string) (interface{}, interface{}, error) {
+	tokens, err := parsePointer(pointer)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, nil, errors.New("cannot remove the whole document")
+	}
+	var removed interface{}
+	doc, err = setIn(doc, tokens, func(parent interface{}, last string) (interface{}, error) {
+		switch node := parent.(type) {
+		case map[string]interface{}:
+			v, ok := node[last]
+			if !ok {
+				return nil, fmt.Errorf("path %q not found", pointer)
+			}
+			removed = v
+			delete(node, last)
+			return node, nil
+		case []interface{}:
+			i, err := arrayIndex(last, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			removed = node[i]
+			return append(node[:i], node[i+1:]...), nil
+		default:
+			return nil, fmt.Errorf("path %q not found", pointer)
+		}
+	})
+	return doc, removed, err
+}
+
+// setIn percorre tokens até o pai do último e aplica change nele, regravando
+// os contêineres no caminho de volta
+func setIn(node interface{}, tokens []string, change func(parent interface{}, last string) (interface{}, error)) (interface{}, error) {
+	if len(tokens) == 1 {
+		return change(node, tokens[0])
+	}
+	token := tokens[0]
+	switch n := node.(type) {
+	case map[string]interface{}:
+		child, ok := n[token]
+		if !ok {
+			return nil, fmt.Errorf("path segment %q not found", token)
+		}
+		updated, err := setIn(child, tokens[1:], change)
+		if err != nil {
+			return nil, err
+		}
+		n[token] = updated
+		return n, nil
+	case []interface{}:
+		i, err := arrayIndex(token, len(n), false)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := setIn(n[i], tokens[1:], change)
+		if err != nil {
+			return nil, err
+		}
+		n[i] = updated
+		return n, nil
+	default:
+		return nil, fmt.Errorf("path segment %q not found", token)
+	}
+}
+
+func deepCopy(v interface{}) interface{} {
+	switch node := v.(type) {
+	case map[string]interface{}:
+		c := make(map[string]interface{}, len(node))
+		for k, child := range node {
+			c[k] = deepCopy(child)
+		}
+		return c
+	case []interface{}:
+		c := make([]interface{}, len(node))
+		for i, child := range node {
+			c[i] = deepCopy(child)
+		}
+		return c
+	default:
+		return v
+	}
+}
+
+// patchDocument expõe o rascunho no formato de PatchProfileDTO, com todos os campos
+func (p *Profile) patchDocument() PatchProfileDTO {
+	return PatchProfileDTO{
+		Headline:          &p.Headline,
+		Bio:               &p.Bio,
+		Seniority:         &p.Seniority,
+		YearsOfExp:        &p.YearsOfExp,
+		OpenToWork:        &p.OpenToWork,
+		SalaryExpectation: &p.SalaryExpectation,
+		Currency:          &p.Currency,
+		ContractType:      &p.ContractType,
+		Location:          &p.Location,
+		RemoteOnly:        &p.RemoteOnly,
+		Skills:            &p.Skills,
+		SocialLinks:       &p.SocialLinks,
+		Experiences:       &p.Experiences,
+		Projects:          &p.Projects,
+		Educations:        &p.Educations,
+		Certifications:    &p.Certifications,
+		Languages:         &p.Languages,
+		Publications:      &p.Publications,
+		Visibility:        &p.Visibility,
+		FieldPrivacy:      &p.FieldPrivacy,
+		Slug:              &p.Slug,
+		Name:              &p.Name,
+		DefaultLocale:     &p.DefaultLocale,
+		Translations:      &p.Translations,
+	}
+}
+
+// genericDocument converte o documento do perfil em mapas e slices genéricos.
+// Membros ausentes ou nulos recebem o valor de um perfil novo, para que
+// caminhos como /translations/en ou /skills/- existam mesmo em perfis antigos.
+func genericDocument(p *Profile) (map[string]interface{}, error) {
+	doc, err := toGeneric(p.patchDocument())
+	if err != nil {
+		return nil, err
+	}
+	return withDefaults(doc)
+}
+
+func withDefaults(doc map[string]interface{}) (map[string]interface{}, error) {
+	defaults, err := toGeneric(NewProfile("").patchDocument())
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range defaults {
+		if doc[key] == nil {
+			doc[key] = value
+		}
+	}
+	return doc, nil
+}
+
+func toGeneric(dto PatchProfileDTO) (map[string]interface{}, error) {
+	raw, err := json.Marshal(dto)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]interface{}
+	err = json.Unmarshal(raw, &doc)
+	return doc, err
+}
+
+// applyDocumentPatch aplica o patch ao rascunho e retorna um PatchProfileDTO só
+// com os campos que mudaram, que são os únicos validados e gravados. Remover
+// um campo volta ao valor de um perfil novo; campos que não fazem parte do
+// documento são recusados.
+func applyDocumentPatch(p *Profile, patch DocumentPatch) (PatchProfileDTO, error) {
+	var dto PatchProfileDTO
+	original, err := genericDocument(p)
+	if err != nil {
+		return dto, err
+	}
+	doc, err := genericDocument(p)
+	if err != nil {
+		return dto, err
+	}
+
+	patched, err := patch.apply(doc)
+	if err != nil {
+		return dto, err
+	}
+	result, ok := patched.(map[string]interface{})
+	if !ok {
+		return dto, fmt.Errorf("%w: the profile document must remain a JSON object", ErrInvalidPatch)
+	}
+	if result, err = withDefaults(result); err != nil {
+		return dto, err
+	}
+
+	changed := map[string]interface{}{}
+	for key, value := range result {
+		if !reflect.DeepEqual(value, original[key]) {
+			changed[key] = value
+		}
+	}
+
+	raw, err := json.Marshal(changed)
+	if err != nil {
+		return dto, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&dto); err != nil {
+		return dto, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	return dto, nil
+}
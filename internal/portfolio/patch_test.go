@@ -0,0 +1,225 @@
+package portfolio
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func genericJSON(t *testing.T, raw string) interface{} {
+	t.Helper()
+	var v interface{}
+	if err := json.Unmarshal([]byte(raw), &v); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestParsePointer(t *testing.T) {
+	tests := []struct {
+		pointer string
+		want    []string
+		wantErr bool
+	}{
+		{"", nil, false},
+		{"/", []string{""}, false},
+		{"/skills/0/name", []string{"skills", "0", "name"}, false},
+		{"/a~1b", []string{"a/b"}, false},
+		{"/m~0n", []string{"m~n"}, false},
+		// ~01 é "~1" literal: ~1 é decodificado antes de ~0
+		{"/~01", []string{"~1"}, false},
+		{"/~0~1", []string{"~/"}, false},
+		{"skills", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := parsePointer(tt.pointer)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parsePointer(%q) error = %v, wantErr %v", tt.pointer, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parsePointer(%q) = %q, want %q", tt.pointer, got, tt.want)
+		}
+	}
+}
+
+func TestJSONPatchApply(t *testing.T) {
+	const doc = `{"a/b": 1, "m~n": 2, "list": [1, 2, 3], "nested": {"x": "y"}}`
+	tests := []struct {
+		name    string
+		patch   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "replace com ~1 no caminho",
+			patch: `[{"op": "replace", "path": "/a~1b", "value": 10}]`,
+			want:  `{"a/b": 10, "m~n": 2, "list": [1, 2, 3], "nested": {"x": "y"}}`,
+		},
+		{
+			name:  "remove com ~0 no caminho",
+			patch: `[{"op": "remove", "path": "/m~0n"}]`,
+			want:  `{"a/b": 1, "list": [1, 2, 3], "nested": {"x": "y"}}`,
+		},
+		{
+			name:  "add no fim do array",
+			patch: `[{"op": "add", "path": "/list/-", "value": 4}]`,
+			want:  `{"a/b": 1, "m~n": 2, "list": [1, 2, 3, 4], "nested": {"x": "y"}}`,
+		},
+		{
+			name:  "add no meio do array",
+			patch: `[{"op": "add", "path": "/list/1", "value": 9}]`,
+			want:  `{"a/b": 1, "m~n": 2, "list": [1, 9, 2, 3], "nested": {"x": "y"}}`,
+		},
+		{
+			name:  "move entre objetos",
+			patch: `[{"op": "move", "from": "/nested/x", "path": "/x"}]`,
+			want:  `{"a/b": 1, "m~n": 2, "list": [1, 2, 3], "nested": {}, "x": "y"}`,
+		},
+		{
+			name:  "test que passa",
+			patch: `[{"op": "test", "path": "/nested", "value": {"x": "y"}}, {"op": "remove", "path": "/list/0"}]`,
+			want:  `{"a/b": 1, "m~n": 2, "list": [2, 3], "nested": {"x": "y"}}`,
+		},
+		{name: "test que falha", patch: `[{"op": "test", "path": "/a~1b", "value": 2}]`, wantErr: true},
+		{name: "test de caminho ausente", patch: `[{"op": "test", "path": "/missing", "value": 1}]`, wantErr: true},
+		{name: "remove de caminho ausente", patch: `[{"op": "remove", "path": "/missing"}]`, wantErr: true},
+		{name: "remove de caminho aninhado ausente", patch: `[{"op": "remove", "path": "/nested/missing/x"}]`, wantErr: true},
+		{name: "remove fora do array", patch: `[{"op": "remove", "path": "/list/3"}]`, wantErr: true},
+		{name: "índice com zero à esquerda", patch: `[{"op": "remove", "path": "/list/01"}]`, wantErr: true},
+		{name: "replace de caminho ausente", patch: `[{"op": "replace", "path": "/missing", "value": 1}]`, wantErr: true},
+		{name: "add sem value", patch: `[{"op": "add", "path": "/x"}]`, wantErr: true},
+		{name: "move para dentro de si mesmo", patch: `[{"op": "move", "from": "/nested", "path": "/nested/inner"}]`, wantErr: true},
+		{name: "operação desconhecida", patch: `[{"op": "merge", "path": "/x", "value": 1}]`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var patch JSONPatch
+			if err := json.Unmarshal([]byte(tt.patch), &patch); err != nil {
+				t.Fatal(err)
+			}
+			got, err := patch.apply(genericJSON(t, doc))
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidPatch) {
+					t.Fatalf("apply() error = %v, want ErrInvalidPatch", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("apply() error = %v", err)
+			}
+			if want := genericJSON(t, tt.want); !reflect.DeepEqual(got, want) {
+				t.Errorf("apply() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestMergePatchApply(t *testing.T) {
+	const doc = `{"headline": "Dev", "bio": "Olá", "socialLinks": {"github": "https://github.com/ana", "website": "https://ana.dev"}, "skills": ["Go", "SQL"]}`
+	tests := []struct {
+		name    string
+		patch   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "null remove o membro",
+			patch: `{"bio": null}`,
+			want:  `{"headline": "Dev", "socialLinks": {"github": "https://github.com/ana", "website": "https://ana.dev"}, "skills": ["Go", "SQL"]}`,
+		},
+		{
+			name:  "objetos são mesclados",
+			patch: `{"socialLinks": {"website": null, "linkedin": "https://linkedin.com/in/ana"}}`,
+			want:  `{"headline": "Dev", "bio": "Olá", "socialLinks": {"github": "https://github.com/ana", "linkedin": "https://linkedin.com/in/ana"}, "skills": ["Go", "SQL"]}`,
+		},
+		{
+			name:  "arrays são substituídos",
+			patch: `{"skills": ["Rust"]}`,
+			want:  `{"headline": "Dev", "bio": "Olá", "socialLinks": {"github": "https://github.com/ana", "website": "https://ana.dev"}, "skills": ["Rust"]}`,
+		},
+		{
+			name:  "null em membro ausente não faz nada",
+			patch: `{"missing": null}`,
+			want:  doc,
+		},
+		{name: "patch que não é objeto", patch: `["bio"]`, wantErr: true},
+		{name: "JSON inválido", patch: `{"bio": `, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MergePatch(tt.patch).apply(genericJSON(t, doc))
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidPatch) {
+					t.Fatalf("apply() error = %v, want ErrInvalidPatch", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("apply() error = %v", err)
+			}
+			if want := genericJSON(t, tt.want); !reflect.DeepEqual(got, want) {
+				t.Errorf("apply() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestApplyDocumentPatch(t *testing.T) {
+	newProfile := func() *Profile {
+		p := NewProfile("u1")
+		p.Headline = "Dev Go"
+		p.Bio = "Olá"
+		p.YearsOfExp = 5
+		return p
+	}
+
+	t.Run("null em merge patch volta ao valor de um perfil novo", func(t *testing.T) {
+		dto, err := applyDocumentPatch(newProfile(), MergePatch(`{"bio": null}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if dto.Bio == nil || *dto.Bio != "" {
+			t.Errorf("Bio = %v, want empty string", dto.Bio)
+		}
+		if dto.Headline != nil || dto.YearsOfExp != nil {
+			t.Errorf("campos não alterados no DTO: headline=%v yearsOfExperience=%v", dto.Headline, dto.YearsOfExp)
+		}
+	})
+
+	t.Run("apenas os campos alterados entram no DTO", func(t *testing.T) {
+		dto, err := applyDocumentPatch(newProfile(), JSONPatch{
+			{Op: "test", Path: "/headline", Value: json.RawMessage(`"Dev Go"`)},
+			{Op: "replace", Path: "/headline", Value: json.RawMessage(`"Dev Rust"`)},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if dto.Headline == nil || *dto.Headline != "Dev Rust" {
+			t.Errorf("Headline = %v, want Dev Rust", dto.Headline)
+		}
+		if dto.Bio != nil {
+			t.Errorf("Bio = %q, want nil", *dto.Bio)
+		}
+	})
+
+	failures := []struct {
+		name  string
+		patch DocumentPatch
+	}{
+		{"campo desconhecido em merge patch", MergePatch(`{"unknownField": 1}`)},
+		{"campo desconhecido em JSON patch", JSONPatch{{Op: "add", Path: "/unknownField", Value: json.RawMessage(`1`)}}},
+		{"tipo errado", MergePatch(`{"yearsOfExperience": "cinco"}`)},
+		{"documento deixa de ser objeto", JSONPatch{{Op: "replace", Path: "", Value: json.RawMessage(`[]`)}}},
+		{"test que falha", JSONPatch{{Op: "test", Path: "/headline", Value: json.RawMessage(`"outro"`)}}},
+		{"remove de caminho ausente", JSONPatch{{Op: "remove", Path: "/socialLinks/missing"}}},
+	}
+	for _, tt := range failures {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := applyDocumentPatch(newProfile(), tt.patch); !errors.Is(err, ErrInvalidPatch) {
+				t.Errorf("applyDocumentPatch() error = %v, want ErrInvalidPatch", err)
+			}
+		})
+	}
+}
@@ -2,107 +2,549 @@ package portfolio
 
 import (
 	"context"
+	"crypto/sha256"
 	"errors"
+	"io"
 	"portfolio/internal/auth"
+	"portfolio/internal/config"
 	"portfolio/internal/search"
+	"portfolio/internal/taxonomy"
+	"strings"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 type PortfolioService struct {
-	repo     ProfileRepository
-	search   search.SearchService
-	userRepo auth.UserRepository
+	repo       ProfileRepository
+	search     search.SearchService
+	userRepo   auth.UserRepository
+	thresholds SeniorityThresholds
+	taxonomy   *taxonomy.TaxonomyService
+	// Endossos de habilidades e recomendações entre usuários
+	endorsements EndorsementRepository
+	// Visitas ao perfil publicado e a chave do hash anônimo dos visitantes
+	views      ProfileViewRepository
+	visitorKey []byte
 }
 
 var localProjectProvider string = "Local"
 type SaveProfileInput struct {
-	Headline          string       `json:"headline"`
-	Bio               string       `json:"bio"`
-	Seniority         Seniority    `json:"seniority"`
-	YearsOfExp        int          `json:"years_of_experience"`
-	OpenToWork        bool         `json:"open_to_work"`
-	SalaryExpectation float64      `json:"salary_expectation"`
-	Currency          string       `json:"currency"`
-	ContractType      string       `json:"contract_type"`
-	Location          LocationType `json:"location"`
-	RemoteOnly        bool         `json:"remote_only"`
-	Skills            []string     `json:"skills"`
-	SocialLinks       SocialLinks  `json:"social_links"`
-	Experiences       Experiences  `json:"experiences"`
-	Projects          Projects     `json:"projects"`
-	Educations        Educations   `json:"educations"`
-}
-
-func NewPortfolioService(repo ProfileRepository, search search.SearchService, userRepo auth.UserRepository) *PortfolioService {
-	return &PortfolioService{repo: repo, search: search, userRepo: userRepo}
+	Name              string        `json:"name"`
+	Headline          string        `json:"headline"`
+	Bio               string        `json:"bio"`
+	Seniority         Seniority     `json:"seniority"`
+	YearsOfExp        int           `json:"years_of_experience"`
+	OpenToWork        bool          `json:"open_to_work"`
+	SalaryExpectation float64       `json:"salary_expectation"`
+	Currency          string        `json:"currency"`
+	ContractType      string        `json:"contract_type"`
+	Location          LocationType  `json:"location"`
+	RemoteOnly        bool          `json:"remote_only"`
+	Skills            Skills        `json:"skills"`
+	SocialLinks       SocialLinks   `json:"social_links"`
+	Experiences       Experiences   `json:"experiences"`
+	Projects          Projects      `json:"projects"`
+	Educations        Educations    `json:"educations"`
+	Visibility        Visibility    `json:"visibility"`
+	FieldPrivacy      *FieldPrivacy `json:"field_privacy"` // nil mantém a privacidade atual
+	Slug              string        `json:"slug"`
+	DefaultLocale     Locale        `json:"default_locale"`
+	Translations      Translations  `json:"translations"` // nil mantém as traduções atuais
+
+	Certifications Certifications `json:"certifications"`
+	Languages      Languages      `json:"languages"`
+	Publications   Publications   `json:"publications"`
+}
+
+func NewPortfolioService(cfg *config.Config, repo ProfileRepository, search search.SearchService, userRepo auth.UserRepository, taxonomy *taxonomy.TaxonomyService, endorsements EndorsementRepository, views ProfileViewRepository) *PortfolioService {
+	thresholds := SeniorityThresholds{
+		MidLevel:  float64(cfg.SeniorityMidLevelYears),
+		Senior:    float64(cfg.SenioritySeniorYears),
+		Lead:      float64(cfg.SeniorityLeadYears),
+		Principal: float64(cfg.SeniorityPrincipalYears),
+	}
+	// A chave do hash dos visitantes deriva do segredo da sessão para sobreviver a
+	// reinícios (e ser a mesma entre instâncias) sem exigir outra configuração
+	visitorKey := sha256.Sum256([]byte("profile-views|" + cfg.SessionKey))
+	return &PortfolioService{repo: repo, search: search, userRepo: userRepo, thresholds: thresholds, taxonomy: taxonomy, endorsements: endorsements,
+		views: views, visitorKey: visitorKey[:]}
 }
 
+// GetMyProfile retorna o perfil principal do usuário
 func (s *PortfolioService) GetMyProfile(ctx context.Context, userID string) (*Profile, error) {
-	return s.repo.FindByUserID(ctx, userID)
+	return s.withComputed(s.repo.FindByUserID(ctx, userID))
+}
+
+// GetMyProfileByID retorna um perfil do usuário. profileID vazio retorna o principal.
+func (s *PortfolioService) GetMyProfileByID(ctx context.Context, userID string, profileID string) (*Profile, error) {
+	return s.withComputed(s.findOwned(ctx, userID, profileID))
+}
+
+// ListMyProfiles retorna todos os perfis do usuário, o principal primeiro
+func (s *PortfolioService) ListMyProfiles(ctx context.Context, userID string) ([]*Profile, error) {
+	profiles, err := s.repo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for _, p := range profiles {
+		p.withComputedExperience(s.thresholds, now)
+	}
+	return profiles, nil
+}
+
+// findOwned busca um perfil garantindo que pertence ao usuário.
+// profileID vazio significa o perfil principal.
+func (s *PortfolioService) findOwned(ctx context.Context, userID string, profileID string) (*Profile, error) {
+	if profileID == "" {
+		return s.repo.FindByUserID(ctx, userID)
+	}
+	profile, err := s.repo.Find(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+	if profile.UserID != userID {
+		return nil, ErrProfileNotFound
+	}
+	return profile, nil
 }
 
 func (s *PortfolioService) GetProfile(ctx context.Context, profileID string) (*Profile, error) {
-	return s.repo.Find(ctx, profileID)
+	return s.withComputed(s.repo.Find(ctx, profileID))
+}
+
+// GetPublishedProfile retorna a versão publicada (visível ao público) do perfil
+func (s *PortfolioService) GetPublishedProfile(ctx context.Context, profileID string) (*Profile, error) {
+	return s.withComputed(s.repo.FindPublished(ctx, profileID))
+}
+
+// GetCompleteness calcula o checklist de preenchimento do rascunho do usuário
+func (s *PortfolioService) GetCompleteness(ctx context.Context, userID string, profileID string) (*Completeness, error) {
+	profile, err := s.findOwned(ctx, userID, profileID)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userRepo.Find(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	c := profile.Completeness(user.ProfileImage != nil && *user.ProfileImage != "")
+	return &c, nil
+}
+
+// SuggestSeniority sugere a senioridade para os anos de experiência informados
+func (s *PortfolioService) SuggestSeniority(years float64) Seniority {
+	return s.thresholds.Suggest(years)
+}
+
+// withComputed preenche os campos derivados (experiência calculada e senioridade sugerida).
+// Recebe o retorno do repositório diretamente para simplificar os chamadores.
+func (s *PortfolioService) withComputed(p *Profile, err error) (*Profile, error) {
+	if err != nil {
+		return nil, err
+	}
+	return p.withComputedExperience(s.thresholds, time.Now()), nil
+}
+
+// GetVisibleProfile retorna a versão publicada do perfil respeitando a visibilidade
+// configurada pelo dono. viewerID é vazio para visitantes anônimos.
+func (s *PortfolioService) GetVisibleProfile(ctx context.Context, profileID string, viewerID string) (*Profile, error) {
+	if uuid.Validate(profileID) != nil {
+		return nil, ErrProfileNotFound
+	}
+	profile, err := s.withComputed(s.repo.FindPublished(ctx, profileID))
+	if err != nil {
+		return nil, err
+	}
+	if err := profile.CanBeViewedBy(viewerID); err != nil {
+		return nil, err
+	}
+
+	viewer := Viewer{UserID: viewerID}
+	if viewerID != "" && viewerID != profile.UserID && profile.FieldPrivacy.RequiresApproval() {
+		approved, err := s.repo.IsApprovedViewer(ctx, profile.ID, viewerID)
+		if err != nil {
+			return nil, err
+		}
+		viewer.Approved = approved
+	}
+	return profile.Redact(viewer), nil
+}
+
+func (s *PortfolioService) ListApprovedViewers(ctx context.Context, userID string, profileID string) ([]ApprovedViewer, error) {
+	profile, err := s.findOwned(ctx, userID, profileID)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.ListApprovedViewers(ctx, profile.ID)
+}
+
+// ApproveViewer libera os campos com audiência APPROVED para o usuário com o email informado
+func (s *PortfolioService) ApproveViewer(ctx context.Context, userID string, profileID string, viewerEmail string) (*ApprovedViewer, error) {
+	profile, err := s.findOwned(ctx, userID, profileID)
+	if err != nil {
+		return nil, err
+	}
+	viewer, err := s.userRepo.FindByEmail(ctx, viewerEmail)
+	if err != nil {
+		return nil, err
+	}
+	if viewer.ID == userID {
+		return nil, ErrCannotApproveSelf
+	}
+	if err := s.repo.AddApprovedViewer(ctx, profile.ID, viewer.ID); err != nil {
+		return nil, err
+	}
+	return &ApprovedViewer{
+		UserID:    viewer.ID,
+		Email:     viewer.Email,
+		FirstName: viewer.FirstName,
+		LastName:  viewer.LastName,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+func (s *PortfolioService) RevokeViewer(ctx context.Context, userID string, profileID string, viewerUserID string) error {
+	profile, err := s.findOwned(ctx, userID, profileID)
+	if err != nil {
+		return err
+	}
+	return s.repo.RemoveApprovedViewer(ctx, profile.ID, viewerUserID)
 }
 
 func (s *PortfolioService) ListProfiles(ctx context.Context, profileIDs []string) ([]*Profile, error) {
-	return s.repo.List(ctx, profileIDs)
+	profiles, err := s.repo.List(ctx, profileIDs)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for _, p := range profiles {
+		p.withComputedExperience(s.thresholds, now)
+	}
+	return profiles, nil
 }
 
 func (s *PortfolioService) CreateProfile(ctx context.Context, userID string, input SaveProfileInput) (*Profile, error) {
-	// Verifica se já existe
-	existing, err := s.repo.FindByUserID(ctx, userID)
-	if err != nil && !errors.Is(err, ErrProfileNotFound) {
+	if err := input.Validate(); err != nil {
 		return nil, err
 	}
-	if existing != nil {
-		return nil, ErrProfileAlreadyExists
+
+	existing, err := s.repo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= MaxProfilesPerUser {
+		return nil, ErrProfileLimitReached
 	}
 
 	profile := NewProfile(userID)
+	// O primeiro perfil do usuário é sempre o principal
+	profile.IsPrimary = len(existing) == 0
 	s.mapInputToProfile(profile, input)
+	if err := s.checkSlug(ctx, profile); err != nil {
+		return nil, err
+	}
 
 	if err := s.repo.Create(ctx, profile); err != nil {
 		return nil, err
 	}
+	if profile.Slug != "" {
+		if err := s.repo.UpdateSlug(ctx, profile.ID, "", profile.Slug); err != nil {
+			return nil, err
+		}
+	}
 
-	go s.sendToIndexing(profile, userID)
-	return profile, nil
+	return s.withComputed(profile, nil)
 }
 
-func (s *PortfolioService) UpdateProfile(ctx context.Context, userID string, input SaveProfileInput) (*Profile, error) {
-	profile, err := s.repo.FindByUserID(ctx, userID)
+// UpdateProfile substitui o conteúdo do perfil. profileID vazio atualiza o principal.
+// expectedVersion é a versão em que o cliente baseou a edição (0 para não verificar).
+func (s *PortfolioService) UpdateProfile(ctx context.Context, userID string, profileID string, expectedVersion int, input SaveProfileInput) (*Profile, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	profile, err := s.findOwned(ctx, userID, profileID)
 	if err != nil {
 		return nil, err
 	}
+	if err := profile.checkVersion(expectedVersion); err != nil {
+		return nil, err
+	}
 
+	previous := *profile
 	s.mapInputToProfile(profile, input)
 	profile.UpdatedAt = time.Now()
 
+	if err := s.checkSlug(ctx, profile); err != nil {
+		return nil, err
+	}
 	if err := s.repo.Update(ctx, profile); err != nil {
 		return nil, err
 	}
-	go s.sendToIndexing(profile, userID)
-	return profile, nil
+	if searchMetadataChanged(&previous, profile) {
+		go s.reindexPublished(profile.ID, userID)
+	}
+	return s.withComputed(profile, nil)
 }
 
-func (s *PortfolioService) PatchProfile(ctx context.Context, userID string, input PatchProfileDTO) (*Profile, error) {
-	profile, err := s.repo.FindByUserID(ctx, userID)
+func (s *PortfolioService) PatchProfile(ctx context.Context, userID string, profileID string, expectedVersion int, input PatchProfileDTO) (*Profile, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	profile, err := s.findOwned(ctx, userID, profileID)
+	if err != nil {
+		return nil, err
+	}
+	if err := profile.checkVersion(expectedVersion); err != nil {
+		return nil, err
+	}
+	return s.savePatch(ctx, userID, profile, input)
+}
+
+// PatchProfileDocument aplica um JSON Merge Patch ou JSON Patch ao documento do
+// rascunho (o mesmo formato de PatchProfileDTO) e valida o resultado antes de salvar
+func (s *PortfolioService) PatchProfileDocument(ctx context.Context, userID string, profileID string, expectedVersion int, patch DocumentPatch) (*Profile, error) {
+	profile, err := s.findOwned(ctx, userID, profileID)
+	if err != nil {
+		return nil, err
+	}
+	if err := profile.checkVersion(expectedVersion); err != nil {
+		return nil, err
+	}
+
+	input, err := applyDocumentPatch(profile, patch)
 	if err != nil {
 		return nil, err
 	}
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+	return s.savePatch(ctx, userID, profile, input)
+}
+
+// savePatch aplica o DTO já validado ao perfil e grava
+func (s *PortfolioService) savePatch(ctx context.Context, userID string, profile *Profile, input PatchProfileDTO) (*Profile, error) {
+	previous := *profile
+	if input.Skills != nil {
+		skills := s.normalizeSkills(*input.Skills)
+		input.Skills = &skills
+	}
 	profile.Update(input)
 
+	if err := s.checkSlug(ctx, profile); err != nil {
+		return nil, err
+	}
 	if err := s.repo.Update(ctx, profile); err != nil {
 		return nil, err
 	}
+	if searchMetadataChanged(&previous, profile) {
+		go s.reindexPublished(profile.ID, userID)
+	}
+	return s.withComputed(profile, nil)
+}
+
+// PublishProfile copia o rascunho para a versão publicada e envia para indexação
+func (s *PortfolioService) PublishProfile(ctx context.Context, userID string, profileID string) (*Profile, error) {
+	profile, err := s.findOwned(ctx, userID, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	publishedAt := time.Now()
+	if err := s.repo.Publish(ctx, profile, publishedAt); err != nil {
+		return nil, err
+	}
+	profile.PublishedAt = &publishedAt
+
 	go s.sendToIndexing(profile, userID)
-	return profile, nil
+	return s.withComputed(profile, nil)
+}
+
+// DeleteProfile move o perfil para a lixeira e o remove da busca. Ele pode ser
+// restaurado por ProfileRestoreWindow; depois disso é apagado pela rotina de limpeza.
+func (s *PortfolioService) DeleteProfile(ctx context.Context, userID string, profileID string) error {
+	profile, err := s.findOwned(ctx, userID, profileID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, profile.ID, time.Now()); err != nil {
+		return err
+	}
+	go s.search.DeleteProfile(profile.ID)
+	return nil
+}
+
+// SetPrimaryProfile define qual perfil é usado nas rotas sem ID e como padrão no editor
+func (s *PortfolioService) SetPrimaryProfile(ctx context.Context, userID string, profileID string) (*Profile, error) {
+	if err := s.repo.SetPrimary(ctx, userID, profileID); err != nil {
+		return nil, err
+	}
+	return s.withComputed(s.findOwned(ctx, userID, profileID))
+}
+
+// DuplicateProfile cria um novo perfil (não publicado e sem slug) a partir de outro,
+// usado para montar variações como "Backend" e "Dados"
+func (s *PortfolioService) DuplicateProfile(ctx context.Context, userID string, profileID string, name string) (*Profile, error) {
+	source, err := s.findOwned(ctx, userID, profileID)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.repo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= MaxProfilesPerUser {
+		return nil, ErrProfileLimitReached
+	}
+
+	name = strings.TrimSpace(name)
+	v := &validator{}
+	v.name("/name", name)
+	v.check(name != "", "/name", "obrigatório")
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	dup := *source
+	fresh := NewProfile(userID)
+	dup.ID = fresh.ID
+	dup.Name = name
+	dup.IsPrimary = false
+	dup.Slug = ""
+	dup.PublishedAt = nil
+	dup.CreatedAt = fresh.CreatedAt
+	dup.UpdatedAt = fresh.UpdatedAt
+	dup.Version = fresh.Version
+
+	if err := s.repo.Create(ctx, &dup); err != nil {
+		return nil, err
+	}
+	return s.withComputed(&dup, nil)
+}
+
+// ExportJSONResume converte o rascunho do perfil para o formato JSON Resume
+func (s *PortfolioService) ExportJSONResume(ctx context.Context, userID string, profileID string) (*JSONResume, error) {
+	profile, err := s.findOwned(ctx, userID, profileID)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userRepo.Find(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	owner := JSONResumeBasics{
+		Name:  strings.TrimSpace(user.FirstName + " " + user.LastName),
+		Email: user.Email,
+	}
+	if user.ProfileImage != nil {
+		owner.Image = *user.ProfileImage
+	}
+	resume := profile.ToJSONResume(owner)
+	return &resume, nil
+}
+
+// ImportJSONResume aplica um currículo JSON Resume ao rascunho do perfil. Com dryRun
+// nada é salvo e o resultado lista o que mudaria. Usuários sem perfil ganham um
+// novo perfil principal.
+func (s *PortfolioService) ImportJSONResume(ctx context.Context, userID string, profileID string, expectedVersion int, resume JSONResume, dryRun bool) (*ImportResult, error) {
+	return s.applyImport(ctx, userID, profileID, expectedVersion, dryRun, func(current *Profile) (PatchProfileDTO, error) {
+		dto := resume.ToPatch(current)
+		if err := dto.Validate(); err != nil {
+			return dto, toResumeErrors(err)
+		}
+		return dto, nil
+	})
+}
+
+// ImportLinkedIn mescla o export do LinkedIn (ZIP de CSVs) ao rascunho do perfil
+// sem apagar dados existentes. Com dryRun nada é salvo.
+func (s *PortfolioService) ImportLinkedIn(ctx context.Context, userID string, profileID string, expectedVersion int, archive io.ReaderAt, size int64, dryRun bool) (*ImportResult, error) {
+	input, warnings, err := ParseLinkedInExport(archive, size)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.applyImport(ctx, userID, profileID, expectedVersion, dryRun, func(current *Profile) (PatchProfileDTO, error) {
+		dto := input.MergeInto(current)
+		return dto, dto.Validate()
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Warnings = warnings
+	return result, nil
+}
+
+// applyImport aplica ao rascunho o PATCH montado por toPatch a partir do perfil atual.
+// expectedVersion é a versão vista na pré-visualização (dry run); 0 não verifica.
+func (s *PortfolioService) applyImport(ctx context.Context, userID string, profileID string, expectedVersion int, dryRun bool, toPatch func(current *Profile) (PatchProfileDTO, error)) (*ImportResult, error) {
+	profile, err := s.findOwned(ctx, userID, profileID)
+	created := false
+	if err != nil {
+		if !errors.Is(err, ErrProfileNotFound) || profileID != "" {
+			return nil, err
+		}
+		// O cliente esperava uma versão de um perfil que não existe mais
+		if expectedVersion != 0 {
+			return nil, ErrVersionConflict
+		}
+		profile = NewProfile(userID)
+		profile.IsPrimary = true
+		created = true
+	} else if err := profile.checkVersion(expectedVersion); err != nil {
+		return nil, err
+	}
+
+	dto, err := toPatch(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := *profile
+	if dto.Skills != nil {
+		skills := s.normalizeSkills(*dto.Skills)
+		dto.Skills = &skills
+	}
+	profile.Update(dto)
+	result := &ImportResult{
+		DryRun:  dryRun,
+		Created: created,
+		Changes: diffImport(&previous, profile),
+	}
+
+	if !dryRun {
+		if created {
+			err = s.repo.Create(ctx, profile)
+		} else {
+			err = s.repo.Update(ctx, profile)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result.Profile, err = s.withComputed(profile, nil)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
 }
 
-func (s *PortfolioService) DeleteProfile(ctx context.Context, userID string) error {
-	go s.search.DeleteProfile(userID)
-	return s.repo.Delete(ctx, userID)
+// normalizeSkills troca apelidos pelo nome canônico da taxonomia (ex: "golang"
+// -> "Go") e descarta as habilidades que ficaram repetidas, mantendo a primeira
+func (s *PortfolioService) normalizeSkills(skills Skills) Skills {
+	normalized := make(Skills, 0, len(skills))
+	for _, skill := range skills {
+		skill.Name = s.taxonomy.Canonical(skill.Name)
+		if normalized.Contains(skill.Name) {
+			continue
+		}
+		normalized = append(normalized, skill)
+	}
+	return normalized
 }
 
 // Helper para mapear DTO -> Entity
@@ -114,6 +556,9 @@ func (s *PortfolioService) mapInputToProfile(p *Profile, input SaveProfileInput)
 		}
 	}
 
+	if input.Name != "" {
+		p.Name = input.Name
+	}
 	p.Headline = input.Headline
 	p.Bio = input.Bio
 	p.Seniority = input.Seniority
@@ -124,30 +569,135 @@ func (s *PortfolioService) mapInputToProfile(p *Profile, input SaveProfileInput)
 	p.ContractType = input.ContractType
 	p.Location = input.Location
 	p.RemoteOnly = input.RemoteOnly
-	p.Skills = StringArray(input.Skills)
+	p.Skills = s.normalizeSkills(input.Skills)
 	p.SocialLinks = input.SocialLinks
 	p.Experiences = input.Experiences
 	p.Projects = input.Projects
 	p.Educations = input.Educations
+	p.Certifications = input.Certifications
+	p.Languages = input.Languages
+	p.Publications = input.Publications
+	if input.Visibility != "" {
+		p.Visibility = input.Visibility
+	}
+	if input.FieldPrivacy != nil {
+		p.FieldPrivacy = *input.FieldPrivacy
+	}
+	// Slug vazio mantém o endereço atual; para removê-lo use PATCH com "slug": ""
+	if input.Slug != "" {
+		p.Slug = input.Slug
+	}
+	if input.DefaultLocale != "" {
+		p.DefaultLocale = input.DefaultLocale
+	}
+	if input.Translations != nil {
+		p.Translations = input.Translations
+	}
+}
+
+// CheckSlugAvailability verifica se o usuário pode usar o slug informado.
+// Retorna nil quando o slug está livre ou já pertence ao perfil do usuário.
+func (s *PortfolioService) CheckSlugAvailability(ctx context.Context, userID string, profileID string, slug string) error {
+	profile, err := s.findOwned(ctx, userID, profileID)
+	if err != nil && !errors.Is(err, ErrProfileNotFound) {
+		return err
+	}
+	if profile == nil {
+		profile = &Profile{}
+	}
+	profile.Slug = NormalizeSlug(slug)
+	return s.checkSlug(ctx, profile)
+}
+
+// ResolveSlug retorna o ID do perfil e o slug atual. Quando o slug informado é
+// antigo, currentSlug é diferente dele e o chamador deve redirecionar.
+func (s *PortfolioService) ResolveSlug(ctx context.Context, slug string) (profileID string, currentSlug string, err error) {
+	slug = NormalizeSlug(slug)
+	profileID, current, err := s.repo.FindSlugOwner(ctx, slug)
+	if err != nil {
+		return "", "", err
+	}
+	if current {
+		return profileID, slug, nil
+	}
+
+	profile, err := s.repo.Find(ctx, profileID)
+	if err != nil {
+		return "", "", err
+	}
+	return profile.ID, profile.Slug, nil
+}
+
+// checkSlug valida o formato e garante que o slug não pertence (nem pertenceu) a outro perfil
+func (s *PortfolioService) checkSlug(ctx context.Context, p *Profile) error {
+	p.Slug = NormalizeSlug(p.Slug)
+	if p.Slug == "" {
+		return nil
+	}
+	if err := ValidateSlug(p.Slug); err != nil {
+		return err
+	}
+
+	ownerID, _, err := s.repo.FindSlugOwner(ctx, p.Slug)
+	if err != nil {
+		if errors.Is(err, ErrProfileNotFound) {
+			return nil
+		}
+		return err
+	}
+	if ownerID != p.ID {
+		return ErrSlugTaken
+	}
+	return nil
+}
+
+// searchMetadataChanged indica se mudou algo que afeta o documento indexado
+// sem depender de uma nova publicação (visibilidade e privacidade de campos)
+func searchMetadataChanged(before, after *Profile) bool {
+	return before.Visibility != after.Visibility || before.FieldPrivacy != after.FieldPrivacy
+}
+
+// reindexPublished reenvia a versão publicada para o índice (ou a remove), usado
+// quando metadados como a visibilidade mudam sem uma nova publicação
+func (s *PortfolioService) reindexPublished(profileID string, userId string) {
+	published, err := s.repo.FindPublished(context.Background(), profileID)
+	if err != nil {
+		return
+	}
+	s.sendToIndexing(published, userId)
 }
 
 func (s *PortfolioService) sendToIndexing(p *Profile, userId string) {
+	// Apenas perfis públicos aparecem na busca
+	if !p.IsSearchable() {
+		s.search.DeleteProfile(p.ID)
+		return
+	}
+	// O índice é público: envia apenas o que um visitante anônimo pode ver
+	p = p.Redact(Viewer{})
+	computedYears := p.Experiences.YearsOfExperience(time.Now())
 
 	user, err := s.userRepo.Find(context.Background(), userId)
 	if err != nil {
 		return
 	}
-	skills := make([]string, len(p.Skills))
-	copy(skills, p.Skills)
-
+	// Perfis publicados antes da taxonomia são indexados já com os nomes canônicos
+	indexedSkills := s.normalizeSkills(p.Skills)
+	skills := indexedSkills.Names()
 	for _, project := range p.Projects {
-		skills = append(skills, project.Tags...)
+		for _, tag := range project.Tags {
+			if tag = s.taxonomy.Canonical(tag); tag != "" && !indexedSkills.Contains(tag) {
+				indexedSkills = append(indexedSkills, Skill{Name: tag})
+				skills = append(skills, tag)
+			}
+		}
 	}
 	userName := user.FirstName + " " + user.LastName
 	profileImg := ""
 	if user.ProfileImage != nil {
 		profileImg = *user.ProfileImage
 	}
+	completeness := p.Completeness(profileImg != "")
 	dto := search.ProfileSearchDTO{
 		ProfileId:         p.ID,
 		Headline:          p.Headline,
@@ -155,6 +705,8 @@ func (s *PortfolioService) sendToIndexing(p *Profile, userId string) {
 		Skills:            skills,
 		Seniority:         p.Seniority.Int(),
 		YearsOfExp:        p.YearsOfExp,
+		ComputedYearsExp:  int(computedYears),
+		CompletenessScore: completeness.Score,
 		Location:          p.Location.Int(),
 		OpenToWork:        p.OpenToWork,
 		ContractType:      p.ContractType,
@@ -163,6 +715,32 @@ func (s *PortfolioService) sendToIndexing(p *Profile, userId string) {
 		UserName:          userName,
 		UserProfileImage:  profileImg,
 		RemoteOnly:        p.RemoteOnly,
+		Locales:           make([]string, 0),
+		I18n:              make(map[string]search.LocalizedText),
+		SkillLevels:       indexedSkills.LevelSearchTerms(),
+		SkillYears:        indexedSkills.YearsSearchTerms(),
+		Languages:         p.Languages.SearchTerms(),
+		Certifications:    make([]string, 0),
+		Publications:      make([]string, 0),
+	}
+	// Endossos de colegas pesam no ranking (ver rankingRules em ConfigureIndex)
+	if endorsements, err := s.GetEndorsements(context.Background(), p, ""); err == nil {
+		dto.EndorsementCount = endorsements.Total()
+	}
+	for _, cert := range p.Certifications.Active(time.Now()) {
+		dto.Certifications = append(dto.Certifications, cert.Name, cert.Issuer)
+	}
+	for _, pub := range p.Publications {
+		dto.Publications = append(dto.Publications, pub.Title)
+	}
+	for _, locale := range p.AvailableLocales() {
+		localized := p.Localize(locale)
+		dto.Locales = append(dto.Locales, string(locale))
+		dto.I18n[string(locale)] = search.LocalizedText{
+			Headline:     localized.Headline,
+			Bio:          localized.Bio,
+			Descriptions: localized.descriptions(),
+		}
 	}
 	s.search.IndexProfile(dto)
 }
@@ -14,6 +14,8 @@ type Seniority string
 
 type LocationType string
 
+type Visibility string
+
 const (
 	Junior    Seniority = "JUNIOR"
 	MidLevel  Seniority = "MID_LEVEL"
@@ -29,6 +31,17 @@ const (
 	LocationAny    LocationType = "ANY"
 )
 
+const (
+	// VisibilityPublic aparece na busca e pode ser aberto por qualquer pessoa
+	VisibilityPublic Visibility = "PUBLIC"
+	// VisibilityUnlisted não aparece na busca, mas pode ser aberto por quem tiver o link
+	VisibilityUnlisted Visibility = "UNLISTED"
+	// VisibilityPrivate só pode ser visto pelo próprio dono
+	VisibilityPrivate Visibility = "PRIVATE"
+	// VisibilityRecruiters só pode ser visto por usuários autenticados (recrutadores)
+	VisibilityRecruiters Visibility = "RECRUITERS_ONLY"
+)
+
 
 
 // Structs
@@ -37,6 +50,9 @@ const (
 type Profile struct {
 	ID                string       `json:"id"`
 	UserID            string       `json:"userId"`
+	Name              string       `json:"name"`
+	IsPrimary         bool         `json:"isPrimary"`
+	Slug              string       `json:"slug"`
 	Headline          string       `json:"headline"`
 	Bio               string       `json:"bio"`
 	Seniority         Seniority    `json:"seniority"`
@@ -47,13 +63,38 @@ type Profile struct {
 	ContractType      string       `json:"contractType"`
 	Location          LocationType `json:"location"`
 	RemoteOnly        bool         `json:"remoteOnly"`
-	Skills            StringArray  `json:"skills"`
+	Skills            Skills       `json:"skills"`
 	SocialLinks       SocialLinks  `json:"socialLinks"`
 	Experiences       Experiences  `json:"experiences"`
 	Projects          Projects     `json:"projects"`
 	Educations        Educations   `json:"educations"`
+
+	// Seções complementares do currículo
+	Certifications Certifications `json:"certifications"`
+	Languages      Languages      `json:"languages"`
+	Publications   Publications   `json:"publications"`
+
+	Visibility        Visibility   `json:"visibility"`
+	FieldPrivacy      FieldPrivacy `json:"fieldPrivacy"`
+	DefaultLocale     Locale       `json:"defaultLocale"`
+	Translations      Translations `json:"translations,omitempty"`
 	CreatedAt         time.Time    `json:"createdAt"`
 	UpdatedAt         time.Time    `json:"updatedAt"`
+	PublishedAt       *time.Time   `json:"publishedAt"`
+	// Version é incrementada a cada atualização do rascunho (concorrência otimista)
+	Version int `json:"version"`
+	// DeletedAt é preenchido quando o perfil está na lixeira
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+
+	// Derivados das experiências (não persistidos)
+	ComputedYearsOfExp float64   `json:"computedYearsOfExperience"`
+	SuggestedSeniority Seniority `json:"suggestedSeniority,omitempty"`
+
+	// Locale é o idioma em que o conteúdo foi montado por Localize (não persistido)
+	Locale Locale `json:"locale,omitempty"`
+
+	// RedactedFields lista os grupos de campos ocultados para o visualizador atual (não persistido)
+	RedactedFields []string `json:"redactedFields,omitempty"`
 }
 
 type PatchProfileDTO struct {
@@ -67,17 +108,26 @@ type PatchProfileDTO struct {
 	ContractType      *string       `json:"contractType,omitempty"`
 	Location          *LocationType `json:"location,omitempty"`
 	RemoteOnly        *bool         `json:"remoteOnly,omitempty"`
-	Skills            *StringArray  `json:"skills,omitempty"`
+	Skills            *Skills       `json:"skills,omitempty"`
 	SocialLinks       *SocialLinks  `json:"socialLinks,omitempty"`
 	Experiences       *Experiences  `json:"experiences,omitempty"`
 	Projects          *Projects     `json:"projects,omitempty"`
 	Educations        *Educations   `json:"educations,omitempty"`
+
+	Certifications *Certifications `json:"certifications,omitempty"`
+	Languages      *Languages      `json:"languages,omitempty"`
+	Publications   *Publications   `json:"publications,omitempty"`
+
+	Visibility        *Visibility   `json:"visibility,omitempty"`
+	FieldPrivacy      *FieldPrivacy `json:"fieldPrivacy,omitempty"`
+	Slug              *string       `json:"slug,omitempty"`
+	Name              *string       `json:"name,omitempty"`
+	DefaultLocale     *Locale       `json:"defaultLocale,omitempty"`
+	Translations      *Translations `json:"translations,omitempty"`
 }
 
 // --- Sub-structs e Tipos para JSONB ---
 
-type StringArray []string
-
 type SocialLinks struct {
 	LinkedIn string `json:"linkedin,omitempty"`
 	GitHub   string `json:"github,omitempty"`
@@ -102,9 +152,58 @@ type Project struct {
 	Tags        []string `json:"tags"`
 	Provider  	*string  `json:"provided,omitempty"` // e.g., GitHub, GitLab, Local
 	ProviderId  *string  `json:"providerId,omitempty"` // e.g., ID from the provider
+	// Galeria do projeto, na ordem de exibição
+	Media []ProjectMedia `json:"media,omitempty"`
 }
 type Projects []Project
 
+// MediaKind identifica o tipo de um item da galeria de um projeto
+type MediaKind string
+
+const (
+	// Imagem enviada pelo usuário (screenshot, diagrama), servida por /media/projects/...
+	MediaImage MediaKind = "image"
+	// Link para um vídeo externo (YouTube, Vimeo, Loom...)
+	MediaVideo MediaKind = "video"
+)
+
+// ProjectMediaURLPrefix é o prefixo das imagens enviadas para projetos
+const ProjectMediaURLPrefix = "/media/projects/"
+
+type ProjectMedia struct {
+	Kind     MediaKind `json:"kind"`
+	URL      string    `json:"url"`
+	ThumbURL string    `json:"thumbUrl,omitempty"`
+	Caption  string    `json:"caption,omitempty"`
+	// Texto alternativo para leitores de tela
+	Alt    string `json:"alt,omitempty"`
+	Width  int    `json:"width,omitempty"`
+	Height int    `json:"height,omitempty"`
+}
+
+func (k MediaKind) IsValid() bool {
+	return k == MediaImage || k == MediaVideo
+}
+
+// Images retorna apenas os itens de imagem (usado nas miniaturas da impressão)
+func (p Project) Images() []ProjectMedia {
+	images := make([]ProjectMedia, 0, len(p.Media))
+	for _, m := range p.Media {
+		if m.Kind == MediaImage {
+			images = append(images, m)
+		}
+	}
+	return images
+}
+
+// AltText retorna o texto alternativo, usando a legenda quando ele não foi informado
+func (m ProjectMedia) AltText() string {
+	if m.Alt != "" {
+		return m.Alt
+	}
+	return m.Caption
+}
+
 type Education struct {
 	Institution string     `json:"institution"`
 	Degree      string     `json:"degree"`
@@ -114,19 +213,90 @@ type Education struct {
 }
 type Educations []Education
 
-// --- Implementação de Valuer/Scanner para JSONB ---
+type Certification struct {
+	Name            string     `json:"name"`
+	Issuer          string     `json:"issuer"`
+	CredentialID    string     `json:"credentialId,omitempty"`
+	VerificationURL string     `json:"verificationUrl,omitempty"`
+	IssueDate       time.Time  `json:"issueDate"`
+	ExpiryDate      *time.Time `json:"expiryDate"` // nil = não expira
+}
+type Certifications []Certification
 
-func (a StringArray) Value() (driver.Value, error) {
-	return json.Marshal(a)
+// IsExpired indica se a certificação já passou da validade
+func (c Certification) IsExpired(now time.Time) bool {
+	return c.ExpiryDate != nil && c.ExpiryDate.Before(now)
 }
-func (a *StringArray) Scan(value interface{}) error {
-	b, ok := value.([]byte)
-	if !ok {
-		return errors.New("type assertion to []byte failed")
+
+// Active retorna as certificações ainda válidas
+func (c Certifications) Active(now time.Time) Certifications {
+	active := make(Certifications, 0, len(c))
+	for _, cert := range c {
+		if !cert.IsExpired(now) {
+			active = append(active, cert)
+		}
+	}
+	return active
+}
+
+// LanguageSkill é um idioma falado pelo candidato. Language é o código ISO 639-1.
+type LanguageSkill struct {
+	Language string        `json:"language"`
+	Level    LanguageLevel `json:"level"`
+}
+type Languages []LanguageSkill
+
+// PublicationKind diferencia artigos, palestras e demais publicações
+type PublicationKind string
+
+const (
+	PublicationArticle PublicationKind = "ARTICLE"
+	PublicationTalk    PublicationKind = "TALK"
+	PublicationPaper   PublicationKind = "PAPER"
+	PublicationBook    PublicationKind = "BOOK"
+	PublicationPodcast PublicationKind = "PODCAST"
+)
+
+type Publication struct {
+	Kind  PublicationKind `json:"kind"`
+	Title string          `json:"title"`
+	// Veículo ou evento (ex: "Medium", "GopherCon Brasil")
+	Publisher   string    `json:"publisher"`
+	Date        time.Time `json:"date"`
+	URL         string    `json:"url,omitempty"`
+	Description string    `json:"description,omitempty"`
+}
+type Publications []Publication
+
+func (k PublicationKind) IsValid() bool {
+	switch k {
+	case PublicationArticle, PublicationTalk, PublicationPaper, PublicationBook, PublicationPodcast:
+		return true
+	default:
+		return false
 	}
-	return json.Unmarshal(b, &a)
 }
 
+// Label retorna o nome do tipo de publicação exibido no portfólio
+func (k PublicationKind) Label() string {
+	switch k {
+	case PublicationArticle:
+		return "Artigo"
+	case PublicationTalk:
+		return "Palestra"
+	case PublicationPaper:
+		return "Artigo científico"
+	case PublicationBook:
+		return "Livro"
+	case PublicationPodcast:
+		return "Podcast"
+	default:
+		return string(k)
+	}
+}
+
+// --- Implementação de Valuer/Scanner para JSONB ---
+
 func (s SocialLinks) Value() (driver.Value, error) {
 	return json.Marshal(s)
 }
@@ -171,17 +341,64 @@ func (ed *Educations) Scan(value interface{}) error {
 	return json.Unmarshal(b, &ed)
 }
 
+func (c Certifications) Value() (driver.Value, error) {
+	return json.Marshal(c)
+}
+func (c *Certifications) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, &c)
+}
+
+func (l Languages) Value() (driver.Value, error) {
+	return json.Marshal(l)
+}
+func (l *Languages) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, &l)
+}
+
+func (pu Publications) Value() (driver.Value, error) {
+	return json.Marshal(pu)
+}
+func (pu *Publications) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, &pu)
+}
+
+// DefaultProfileName é o nome dado ao perfil quando o usuário não informa um
+const DefaultProfileName = "Principal"
+
+// MaxProfilesPerUser limita quantos portfólios um usuário pode manter
+const MaxProfilesPerUser = 5
+
 // Factory
 func NewProfile(userID string) *Profile {
 	return &Profile{
-		ID:          uuid.New().String(),
-		UserID:      userID,
-		Skills:      make(StringArray, 0),
-		Experiences: make(Experiences, 0),
-		Projects:    make(Projects, 0),
-		Educations:  make(Educations, 0),
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		ID:             uuid.New().String(),
+		UserID:         userID,
+		Skills:         make(Skills, 0),
+		Experiences:    make(Experiences, 0),
+		Projects:       make(Projects, 0),
+		Educations:     make(Educations, 0),
+		Certifications: make(Certifications, 0),
+		Languages:      make(Languages, 0),
+		Publications:   make(Publications, 0),
+		Visibility:     VisibilityPublic,
+		Name:           DefaultProfileName,
+		DefaultLocale:  DefaultLocale,
+		Translations:   make(Translations),
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+		Version:        1,
 	}
 }
 
@@ -201,10 +418,47 @@ func (p *Profile) Update(dto PatchProfileDTO) {
 	updateIfNotNil(&p.Experiences, dto.Experiences)
 	updateIfNotNil(&p.Projects, dto.Projects)
 	updateIfNotNil(&p.Educations, dto.Educations)
+	updateIfNotNil(&p.Certifications, dto.Certifications)
+	updateIfNotNil(&p.Languages, dto.Languages)
+	updateIfNotNil(&p.Publications, dto.Publications)
+	updateIfNotNil(&p.Visibility, dto.Visibility)
+	updateIfNotNil(&p.FieldPrivacy, dto.FieldPrivacy)
+	updateIfNotNil(&p.Slug, dto.Slug)
+	updateIfNotNil(&p.Name, dto.Name)
+	updateIfNotNil(&p.DefaultLocale, dto.DefaultLocale)
+	updateIfNotNil(&p.Translations, dto.Translations)
 
 	p.UpdatedAt = time.Now()
 }
 
+// CanBeViewedBy verifica se o usuário (vazio quando anônimo) pode abrir o perfil
+func (p *Profile) CanBeViewedBy(viewerID string) error {
+	switch p.Visibility {
+	case VisibilityPrivate:
+		if viewerID != p.UserID {
+			return ErrProfileNotFound
+		}
+	case VisibilityRecruiters:
+		if viewerID == "" {
+			return ErrProfileRestricted
+		}
+	}
+	return nil
+}
+
+// IsSearchable indica se o perfil deve estar no índice de busca
+func (p *Profile) IsSearchable() bool {
+	return p.Visibility == VisibilityPublic || p.Visibility == ""
+}
+
+// HasUnpublishedChanges indica se o rascunho foi alterado desde a última publicação
+func (p *Profile) HasUnpublishedChanges() bool {
+	if p.PublishedAt == nil {
+		return true
+	}
+	return p.UpdatedAt.After(*p.PublishedAt)
+}
+
 func updateIfNotNil[T any](target *T, source *T) {
 	if source != nil {
 		*target = *source
@@ -228,6 +482,33 @@ func (s Seniority) Int() int {
 	}
 }
 
+// Label retorna o nome da senioridade exibido nos currículos exportados
+func (s Seniority) Label() string {
+	switch s {
+	case Junior:
+		return "Júnior"
+	case MidLevel:
+		return "Pleno"
+	case Senior:
+		return "Sênior"
+	case Lead:
+		return "Tech Lead"
+	case Principal:
+		return "Principal"
+	default:
+		return string(s)
+	}
+}
+
+func (v Visibility) IsValid() bool {
+	switch v {
+	case VisibilityPublic, VisibilityUnlisted, VisibilityPrivate, VisibilityRecruiters:
+		return true
+	default:
+		return false
+	}
+}
+
 func (l LocationType) Int() int {
 	switch l {
 	case LocationOnSite:
@@ -241,4 +522,20 @@ func (l LocationType) Int() int {
 	default:
 		return 0
 	}
-}
\ No newline at end of file
+}
+
+// Label retorna o nome da modalidade exibido nos currículos exportados
+func (l LocationType) Label() string {
+	switch l {
+	case LocationOnSite:
+		return "Presencial"
+	case LocationRemote:
+		return "Remoto"
+	case LocationHybrid:
+		return "Híbrido"
+	case LocationAny:
+		return "Qualquer"
+	default:
+		return string(l)
+	}
+}
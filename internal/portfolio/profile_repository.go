@@ -3,9 +3,13 @@ package portfolio
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 type ProfileRepository interface {
@@ -14,31 +18,66 @@ type ProfileRepository interface {
 	Create(ctx context.Context, profile *Profile) error
 	Update(ctx context.Context, profile *Profile) error
 	FindByUserID(ctx context.Context, userID string) (*Profile, error)
-	Delete(ctx context.Context, userID string) error
+	ListByUserID(ctx context.Context, userID string) ([]*Profile, error)
+	// Delete marca o perfil como excluído; ele deixa de aparecer em todas as leituras
+	Delete(ctx context.Context, profileID string, deletedAt time.Time) error
+	// ListDeleted lista os perfis do usuário excluídos depois de since
+	ListDeleted(ctx context.Context, userID string, since time.Time) ([]*Profile, error)
+	// Restore desfaz a exclusão feita depois de since
+	Restore(ctx context.Context, userID string, profileID string, since time.Time) error
+	// Purge remove definitivamente os perfis excluídos até before
+	Purge(ctx context.Context, before time.Time) (int64, error)
+	SetPrimary(ctx context.Context, userID string, profileID string) error
+	Publish(ctx context.Context, profile *Profile, publishedAt time.Time) error
+	FindPublished(ctx context.Context, profileID string) (*Profile, error)
+	ListApprovedViewers(ctx context.Context, profileID string) ([]ApprovedViewer, error)
+	AddApprovedViewer(ctx context.Context, profileID string, viewerUserID string) error
+	RemoveApprovedViewer(ctx context.Context, profileID string, viewerUserID string) error
+	IsApprovedViewer(ctx context.Context, profileID string, viewerUserID string) (bool, error)
+	UpdateSlug(ctx context.Context, profileID string, oldSlug string, newSlug string) error
+	FindSlugOwner(ctx context.Context, slug string) (profileID string, current bool, err error)
 }
 
 type profileRepo struct {
 	db *sql.DB
 }
 
-func NewProfileRepository(db *sql.DB) ProfileRepository {
-	return &profileRepo{db: db}
+// profileColumns lista as colunas lidas em todos os SELECTs de perfil (versão rascunho)
+const profileColumns = `id, user_id, headline, bio, seniority, years_of_experience, open_to_work,
+		       salary_expectation, currency, contract_type, location, remote_only,
+		       skills, social_links, experiences, projects, educations, created_at, updated_at,
+		       published_at, visibility, field_privacy, COALESCE(slug, ''), name, is_primary,
+		       default_locale, translations, certifications, languages, publications, version, deleted_at`
+
+// rowScanner é satisfeito tanto por *sql.Row quanto por *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
 }
 
-func (r *profileRepo) Find(ctx context.Context, profileID string) (*Profile, error) {
-	query := `
-		SELECT id, user_id, headline, bio, seniority, years_of_experience, open_to_work,
-		       salary_expectation, currency, contract_type, location, remote_only,
-		       skills, social_links, experiences, projects, educations, created_at, updated_at
-		FROM profiles WHERE id = $1 LIMIT 1
-	`
+func scanProfile(row rowScanner) (*Profile, error) {
 	p := &Profile{}
-	err := r.db.QueryRowContext(ctx, query, profileID).Scan(
+	err := row.Scan(
 		&p.ID, &p.UserID, &p.Headline, &p.Bio, &p.Seniority, &p.YearsOfExp, &p.OpenToWork,
 		&p.SalaryExpectation, &p.Currency, &p.ContractType, &p.Location, &p.RemoteOnly,
 		&p.Skills, &p.SocialLinks, &p.Experiences, &p.Projects, &p.Educations, &p.CreatedAt, &p.UpdatedAt,
+		&p.PublishedAt, &p.Visibility, &p.FieldPrivacy, &p.Slug, &p.Name, &p.IsPrimary,
+		&p.DefaultLocale, &p.Translations, &p.Certifications, &p.Languages, &p.Publications, &p.Version,
+		&p.DeletedAt,
 	)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
 
+func NewProfileRepository(db *sql.DB) ProfileRepository {
+	return &profileRepo{db: db}
+}
+
+func (r *profileRepo) Find(ctx context.Context, profileID string) (*Profile, error) {
+	query := `SELECT ` + profileColumns + ` FROM profiles WHERE id = $1 AND deleted_at IS NULL LIMIT 1`
+
+	p, err := scanProfile(r.db.QueryRowContext(ctx, query, profileID))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrProfileNotFound
@@ -61,12 +100,7 @@ func (r *profileRepo) List(ctx context.Context, profileIDs []string) ([]*Profile
 		args[i] = id
 	}
 
-	query := fmt.Sprintf(`
-		SELECT id, user_id, headline, bio, seniority, years_of_experience, open_to_work,
-		       salary_expectation, currency, contract_type, location, remote_only,
-		       skills, social_links, experiences, projects, educations, created_at, updated_at
-		FROM profiles WHERE id IN (%s)
-	`, strings.Join(placeholders, ","))
+	query := fmt.Sprintf(`SELECT `+profileColumns+` FROM profiles WHERE id IN (%s) AND deleted_at IS NULL`, strings.Join(placeholders, ","))
 
 	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -76,12 +110,7 @@ func (r *profileRepo) List(ctx context.Context, profileIDs []string) ([]*Profile
 
 	var profiles []*Profile
 	for rows.Next() {
-		p := &Profile{}
-		err := rows.Scan(
-			&p.ID, &p.UserID, &p.Headline, &p.Bio, &p.Seniority, &p.YearsOfExp, &p.OpenToWork,
-			&p.SalaryExpectation, &p.Currency, &p.ContractType, &p.Location, &p.RemoteOnly,
-			&p.Skills, &p.SocialLinks, &p.Experiences, &p.Projects, &p.Educations, &p.CreatedAt, &p.UpdatedAt,
-		)
+		p, err := scanProfile(rows)
 		if err != nil {
 			return nil, err
 		}
@@ -98,31 +127,192 @@ func (r *profileRepo) Create(ctx context.Context, p *Profile) error {
 		INSERT INTO profiles (
 			id, user_id, headline, bio, seniority, years_of_experience, open_to_work,
 			salary_expectation, currency, contract_type, location, remote_only,
-			skills, social_links, experiences, projects, educations, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+			skills, social_links, experiences, projects, educations, created_at, updated_at,
+			visibility, field_privacy, name, is_primary, default_locale, translations,
+			certifications, languages, publications, version
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29)
 	`
 	_, err := r.db.ExecContext(ctx, query,
 		p.ID, p.UserID, p.Headline, p.Bio, p.Seniority, p.YearsOfExp, p.OpenToWork,
 		p.SalaryExpectation, p.Currency, p.ContractType, p.Location, p.RemoteOnly,
 		p.Skills, p.SocialLinks, p.Experiences, p.Projects, p.Educations, p.CreatedAt, p.UpdatedAt,
+		p.Visibility, p.FieldPrivacy, p.Name, p.IsPrimary, p.ContentLocale(), p.Translations,
+		p.Certifications, p.Languages, p.Publications, p.Version,
 	)
 	return err
 }
 
+// Update grava o rascunho somente se a versão no banco ainda for p.Version, e
+// então incrementa p.Version. Se outra requisição salvou antes, retorna
+// ErrVersionConflict sem alterar nada. A troca de slug (com histórico) vai na
+// mesma transação, para não ficar gravada quando o conteúdo é rejeitado.
 func (r *profileRepo) Update(ctx context.Context, p *Profile) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var currentSlug string
+	err = tx.QueryRowContext(ctx,
+		`SELECT COALESCE(slug, '') FROM profiles WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL FOR UPDATE`,
+		p.ID, p.UserID,
+	).Scan(&currentSlug)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrProfileNotFound
+		}
+		return err
+	}
+
 	query := `
 		UPDATE profiles SET
 			headline=$1, bio=$2, seniority=$3, years_of_experience=$4, open_to_work=$5,
 			salary_expectation=$6, currency=$7, contract_type=$8, location=$9, remote_only=$10,
-			skills=$11, social_links=$12, experiences=$13, projects=$14, educations=$15, updated_at=$16
-		WHERE user_id = $17
+			skills=$11, social_links=$12, experiences=$13, projects=$14, educations=$15, updated_at=$16,
+			visibility=$17, field_privacy=$18, name=$19, default_locale=$20, translations=$21,
+			certifications=$22, languages=$23, publications=$24, version = version + 1
+		WHERE id = $25 AND user_id = $26 AND version = $27 AND deleted_at IS NULL
+		RETURNING version
 	`
-	result, err := r.db.ExecContext(ctx, query,
+	var version int
+	err = tx.QueryRowContext(ctx, query,
 		p.Headline, p.Bio, p.Seniority, p.YearsOfExp, p.OpenToWork,
 		p.SalaryExpectation, p.Currency, p.ContractType, p.Location, p.RemoteOnly,
 		p.Skills, p.SocialLinks, p.Experiences, p.Projects, p.Educations, p.UpdatedAt,
-		p.UserID,
-	)
+		p.Visibility, p.FieldPrivacy, p.Name, p.ContentLocale(), p.Translations,
+		p.Certifications, p.Languages, p.Publications,
+		p.ID, p.UserID, p.Version,
+	).Scan(&version)
+	if err != nil {
+		// A linha existe (foi travada acima), então só a versão pode ter mudado
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrVersionConflict
+		}
+		return err
+	}
+
+	if currentSlug != p.Slug {
+		if err := changeSlug(ctx, tx, p.ID, currentSlug, p.Slug); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	p.Version = version
+	return nil
+}
+
+// FindByUserID retorna o perfil principal do usuário
+func (r *profileRepo) FindByUserID(ctx context.Context, userID string) (*Profile, error) {
+	query := `SELECT ` + profileColumns + ` FROM profiles WHERE user_id = $1 AND deleted_at IS NULL ORDER BY is_primary DESC, created_at LIMIT 1`
+
+	p, err := scanProfile(r.db.QueryRowContext(ctx, query, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrProfileNotFound
+		}
+		return nil, err
+	}
+	return p, nil
+}
+
+// ListByUserID retorna todos os perfis do usuário, o principal primeiro
+func (r *profileRepo) ListByUserID(ctx context.Context, userID string) ([]*Profile, error) {
+	query := `SELECT ` + profileColumns + ` FROM profiles WHERE user_id = $1 AND deleted_at IS NULL ORDER BY is_primary DESC, created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	profiles := []*Profile{}
+	for rows.Next() {
+		p, err := scanProfile(rows)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+// Delete marca o perfil como excluído. Se ele era o principal, o perfil mais
+// antigo restante do usuário passa a ser o principal. O slug continua reservado
+// enquanto o perfil puder ser restaurado.
+func (r *profileRepo) Delete(ctx context.Context, profileID string, deletedAt time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var userID string
+	var wasPrimary bool
+	query := `SELECT user_id, is_primary FROM profiles WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`
+	err = tx.QueryRowContext(ctx, query, profileID).Scan(&userID, &wasPrimary)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrProfileNotFound
+		}
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE profiles SET deleted_at = $2, is_primary = FALSE WHERE id = $1`, profileID, deletedAt)
+	if err != nil {
+		return err
+	}
+
+	if wasPrimary {
+		_, err = tx.ExecContext(ctx, `
+			UPDATE profiles SET is_primary = TRUE
+			WHERE id = (SELECT id FROM profiles WHERE user_id = $1 AND deleted_at IS NULL ORDER BY created_at LIMIT 1)
+		`, userID)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *profileRepo) ListDeleted(ctx context.Context, userID string, since time.Time) ([]*Profile, error) {
+	query := `SELECT ` + profileColumns + ` FROM profiles WHERE user_id = $1 AND deleted_at > $2 ORDER BY deleted_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	profiles := []*Profile{}
+	for rows.Next() {
+		p, err := scanProfile(rows)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+// Restore desfaz a exclusão. O perfil volta como principal se o usuário ficou sem nenhum.
+func (r *profileRepo) Restore(ctx context.Context, userID string, profileID string, since time.Time) error {
+	query := `
+		UPDATE profiles p SET deleted_at = NULL,
+			is_primary = NOT EXISTS (
+				SELECT 1 FROM profiles o WHERE o.user_id = p.user_id AND o.is_primary AND o.deleted_at IS NULL
+			)
+		WHERE p.id = $1 AND p.user_id = $2 AND p.deleted_at > $3
+	`
+	result, err := r.db.ExecContext(ctx, query, profileID, userID, since)
 	if err != nil {
 		return err
 	}
@@ -136,41 +326,209 @@ func (r *profileRepo) Update(ctx context.Context, p *Profile) error {
 	return nil
 }
 
-func (r *profileRepo) FindByUserID(ctx context.Context, userID string) (*Profile, error) {
+// Purge apaga de vez os perfis excluídos; endossos, recomendações e histórico
+// de slugs saem junto pelo ON DELETE CASCADE
+func (r *profileRepo) Purge(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM profiles WHERE deleted_at IS NOT NULL AND deleted_at <= $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// SetPrimary marca o perfil como principal do usuário, desmarcando os demais
+func (r *profileRepo) SetPrimary(ctx context.Context, userID string, profileID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `UPDATE profiles SET is_primary = FALSE WHERE user_id = $1 AND is_primary`, userID)
+	if err != nil {
+		return err
+	}
+	result, err := tx.ExecContext(ctx, `UPDATE profiles SET is_primary = TRUE WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL`, profileID, userID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrProfileNotFound
+	}
+	return tx.Commit()
+}
+
+// Publish copia o rascunho atual para a coluna published_data
+func (r *profileRepo) Publish(ctx context.Context, p *Profile, publishedAt time.Time) error {
+	snapshot, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE profiles SET published_data = $1, published_at = $2 WHERE id = $3 AND deleted_at IS NULL`
+	result, err := r.db.ExecContext(ctx, query, snapshot, publishedAt, p.ID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrProfileNotFound
+	}
+	return nil
+}
+
+// FindPublished retorna a versão publicada do perfil. Perfis nunca publicados
+// são tratados como inexistentes para o público. Metadados que não fazem parte
+// do conteúdo (ex: visibilidade, slug) são sempre lidos da linha atual.
+func (r *profileRepo) FindPublished(ctx context.Context, profileID string) (*Profile, error) {
 	query := `
-		SELECT id, user_id, headline, bio, seniority, years_of_experience, open_to_work,
-		       salary_expectation, currency, contract_type, location, remote_only,
-		       skills, social_links, experiences, projects, educations, created_at, updated_at
-		FROM profiles WHERE user_id = $1 LIMIT 1
+		SELECT published_data, published_at, visibility, field_privacy, COALESCE(slug, '')
+		FROM profiles WHERE id = $1 AND published_data IS NOT NULL AND deleted_at IS NULL LIMIT 1
 	`
-	p := &Profile{}
-	err := r.db.QueryRowContext(ctx, query, userID).Scan(
-		&p.ID, &p.UserID, &p.Headline, &p.Bio, &p.Seniority, &p.YearsOfExp, &p.OpenToWork,
-		&p.SalaryExpectation, &p.Currency, &p.ContractType, &p.Location, &p.RemoteOnly,
-		&p.Skills, &p.SocialLinks, &p.Experiences, &p.Projects, &p.Educations, &p.CreatedAt, &p.UpdatedAt,
-	)
-
+	var snapshot []byte
+	var publishedAt time.Time
+	var visibility Visibility
+	var fieldPrivacy FieldPrivacy
+	var slug string
+	err := r.db.QueryRowContext(ctx, query, profileID).Scan(&snapshot, &publishedAt, &visibility, &fieldPrivacy, &slug)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrProfileNotFound
 		}
 		return nil, err
 	}
+
+	p := &Profile{}
+	if err := json.Unmarshal(snapshot, p); err != nil {
+		return nil, err
+	}
+	p.PublishedAt = &publishedAt
+	p.Visibility = visibility
+	p.FieldPrivacy = fieldPrivacy
+	p.Slug = slug
 	return p, nil
 }
 
-func (r *profileRepo) Delete(ctx context.Context, userID string) error {
-	query := `DELETE FROM profiles WHERE user_id = $1`
-	result, err := r.db.ExecContext(ctx, query, userID)
+func (r *profileRepo) ListApprovedViewers(ctx context.Context, profileID string) ([]ApprovedViewer, error) {
+	query := `
+		SELECT v.viewer_user_id, u.email, u.first_name, u.last_name, v.created_at
+		FROM profile_approved_viewers v
+		JOIN users u ON u.id = v.viewer_user_id
+		WHERE v.profile_id = $1
+		ORDER BY v.created_at DESC
+	`
+	rows, err := r.db.QueryContext(ctx, query, profileID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	viewers := []ApprovedViewer{}
+	for rows.Next() {
+		var v ApprovedViewer
+		if err := rows.Scan(&v.UserID, &v.Email, &v.FirstName, &v.LastName, &v.CreatedAt); err != nil {
+			return nil, err
+		}
+		viewers = append(viewers, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return viewers, nil
+}
+
+func (r *profileRepo) AddApprovedViewer(ctx context.Context, profileID string, viewerUserID string) error {
+	query := `
+		INSERT INTO profile_approved_viewers (profile_id, viewer_user_id, created_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (profile_id, viewer_user_id) DO NOTHING
+	`
+	_, err := r.db.ExecContext(ctx, query, profileID, viewerUserID)
+	return err
+}
+
+func (r *profileRepo) RemoveApprovedViewer(ctx context.Context, profileID string, viewerUserID string) error {
+	query := `DELETE FROM profile_approved_viewers WHERE profile_id = $1 AND viewer_user_id = $2`
+	_, err := r.db.ExecContext(ctx, query, profileID, viewerUserID)
+	return err
+}
+
+func (r *profileRepo) IsApprovedViewer(ctx context.Context, profileID string, viewerUserID string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM profile_approved_viewers WHERE profile_id = $1 AND viewer_user_id = $2)`
+	var exists bool
+	err := r.db.QueryRowContext(ctx, query, profileID, viewerUserID).Scan(&exists)
+	return exists, err
+}
+
+// UpdateSlug troca o slug do perfil e guarda o anterior no histórico para redirecionamento
+func (r *profileRepo) UpdateSlug(ctx context.Context, profileID string, oldSlug string, newSlug string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
-	rows, err := result.RowsAffected()
+	defer tx.Rollback()
+
+	if err := changeSlug(ctx, tx, profileID, oldSlug, newSlug); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// changeSlug grava o novo slug e o histórico dentro da transação informada
+func changeSlug(ctx context.Context, tx *sql.Tx, profileID string, oldSlug string, newSlug string) error {
+	if oldSlug != "" {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO profile_slug_history (slug, profile_id, created_at)
+			VALUES ($1, $2, NOW())
+			ON CONFLICT (slug) DO UPDATE SET profile_id = EXCLUDED.profile_id, created_at = NOW()
+		`, oldSlug, profileID)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Se o usuário voltar para um slug antigo ele deixa de ser um redirecionamento
+	_, err := tx.ExecContext(ctx, `DELETE FROM profile_slug_history WHERE slug = $1 AND profile_id = $2`, newSlug, profileID)
 	if err != nil {
 		return err
 	}
-	if rows == 0 {
-		return ErrProfileNotFound
+
+	_, err = tx.ExecContext(ctx, `UPDATE profiles SET slug = NULLIF($1, '') WHERE id = $2`, newSlug, profileID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return ErrSlugTaken
+		}
+		return err
 	}
 	return nil
 }
+
+// FindSlugOwner retorna o perfil dono do slug, indicando se é o slug atual
+// ou um slug antigo guardado no histórico
+func (r *profileRepo) FindSlugOwner(ctx context.Context, slug string) (string, bool, error) {
+	query := `
+		SELECT id, TRUE FROM profiles WHERE slug = $1
+		UNION ALL
+		SELECT profile_id, FALSE FROM profile_slug_history WHERE slug = $1
+		ORDER BY 2 DESC
+		LIMIT 1
+	`
+	var profileID string
+	var current bool
+	err := r.db.QueryRowContext(ctx, query, slug).Scan(&profileID, &current)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", false, ErrProfileNotFound
+		}
+		return "", false, err
+	}
+	return profileID, current, nil
+}
@@ -0,0 +1,132 @@
+package portfolio
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Janela padrão (e máxima) das estatísticas de visualização, em dias
+const (
+	DefaultAnalyticsDays = 30
+	MaxAnalyticsDays     = 365
+	maxTopReferrers      = 5
+)
+
+// ViewKind é o tipo de acesso ao perfil publicado
+type ViewKind string
+
+const (
+	ViewPage  ViewKind = "VIEW"
+	ViewPrint ViewKind = "PRINT"
+	ViewPDF   ViewKind = "PDF"
+)
+
+// ViewSource é a origem da visita, deduzida do Referer
+type ViewSource string
+
+const (
+	SourceDirect   ViewSource = "DIRECT"
+	SourceSearch   ViewSource = "SEARCH"
+	SourceReferral ViewSource = "REFERRAL"
+)
+
+// ProfileVisit descreve um acesso ao perfil como chegou na requisição. O IP só
+// é usado para calcular o hash do visitante e nunca é gravado.
+type ProfileVisit struct {
+	ProfileID    string
+	Kind         ViewKind
+	ViewerUserID string
+	ClientIP     string
+	UserAgent    string
+	Source       ViewSource
+	ReferrerHost string
+	At           time.Time
+}
+
+// ProfileView é a visita já anonimizada, como é guardada
+type ProfileView struct {
+	ProfileID    string
+	Kind         ViewKind
+	Day          time.Time
+	VisitorHash  string
+	Source       ViewSource
+	ReferrerHost string
+	CreatedAt    time.Time
+}
+
+// DailyViews é a contagem de visualizações da página em um dia
+type DailyViews struct {
+	Day   time.Time `json:"day"`
+	Views int       `json:"views"`
+	// Percent é a altura da barra no gráfico, relativa ao dia com mais visitas
+	Percent int `json:"-"`
+}
+
+// ReferrerCount é a quantidade de visitas vindas de um site externo
+type ReferrerCount struct {
+	Host  string `json:"host"`
+	Views int    `json:"views"`
+}
+
+// ProfileAnalytics são as estatísticas agregadas do perfil exibidas ao dono
+type ProfileAnalytics struct {
+	Days       int             `json:"days"`
+	Views      int             `json:"views"`
+	Prints     int             `json:"prints"`
+	Downloads  int             `json:"downloads"`
+	FromSearch int             `json:"fromSearch"`
+	Daily      []DailyViews    `json:"daily"`
+	Referrers  []ReferrerCount `json:"referrers"`
+}
+
+// botUserAgent reconhece crawlers, pré-visualizadores de link e clientes HTTP
+// de linha de comando, que não contam como visitas
+var botUserAgent = regexp.MustCompile(`(?i)bot|crawl|spider|slurp|facebookexternalhit|embedly|preview|headless|lighthouse|curl|wget|python-requests|go-http-client|okhttp|httpclient|java/|libwww|scrapy`)
+
+// IsBotUserAgent indica se o user agent é de um robô (ou está vazio)
+func IsBotUserAgent(userAgent string) bool {
+	userAgent = strings.TrimSpace(userAgent)
+	return userAgent == "" || botUserAgent.MatchString(userAgent)
+}
+
+// ViewDay é o dia (UTC) em que a visita é contada
+func ViewDay(at time.Time) time.Time {
+	return at.UTC().Truncate(24 * time.Hour)
+}
+
+// visitorHash identifica o visitante dentro de um dia: o usuário logado pelo ID,
+// o anônimo pelo IP + user agent. O dia entra no HMAC, então o hash muda todo
+// dia e não permite seguir o visitante nem recuperar o IP.
+func visitorHash(key []byte, visit ProfileVisit) string {
+	identity := "anon|" + visit.ClientIP + "|" + visit.UserAgent
+	if visit.ViewerUserID != "" {
+		identity = "user|" + visit.ViewerUserID
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(ViewDay(visit.At).Format("2006-01-02") + "|" + identity))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// fillDailyViews completa os dias sem visitas com zero e calcula a altura das barras
+func fillDailyViews(counts map[string]int, since time.Time, days int) []DailyViews {
+	daily := make([]DailyViews, 0, days)
+	highest := 0
+	for i := 0; i < days; i++ {
+		day := since.AddDate(0, 0, i)
+		views := counts[day.Format("2006-01-02")]
+		if views > highest {
+			highest = views
+		}
+		daily = append(daily, DailyViews{Day: day, Views: views})
+	}
+	for i := range daily {
+		if highest > 0 {
+			daily[i].Percent = daily[i].Views * 100 / highest
+		}
+	}
+	return daily
+}
@@ -0,0 +1,125 @@
+package portfolio
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// ProfileViewRepository guarda as visitas ao perfil publicado
+type ProfileViewRepository interface {
+	// RecordView não faz nada se o visitante já foi contado no dia para o mesmo tipo
+	RecordView(ctx context.Context, view *ProfileView) error
+	// CountViews conta as visitas do perfil desde o dia informado, por tipo
+	CountViews(ctx context.Context, profileID string, since time.Time) (map[ViewKind]int, error)
+	// CountSearchViews conta as visitas à página que vieram da busca
+	CountSearchViews(ctx context.Context, profileID string, since time.Time) (int, error)
+	// DailyViews conta as visitas à página por dia (chave no formato 2006-01-02)
+	DailyViews(ctx context.Context, profileID string, since time.Time) (map[string]int, error)
+	// TopReferrers lista os sites externos que mais trouxeram visitas à página
+	TopReferrers(ctx context.Context, profileID string, since time.Time, limit int) ([]ReferrerCount, error)
+}
+
+type profileViewRepo struct {
+	db *sql.DB
+}
+
+func NewProfileViewRepository(db *sql.DB) ProfileViewRepository {
+	return &profileViewRepo{db: db}
+}
+
+func (r *profileViewRepo) RecordView(ctx context.Context, view *ProfileView) error {
+	query := `
+		INSERT INTO profile_views (profile_id, kind, day, visitor_hash, source, referrer_host, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (profile_id, kind, day, visitor_hash) DO NOTHING
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		view.ProfileID, view.Kind, view.Day, view.VisitorHash,
+		view.Source, view.ReferrerHost, view.CreatedAt,
+	)
+	return err
+}
+
+func (r *profileViewRepo) CountViews(ctx context.Context, profileID string, since time.Time) (map[ViewKind]int, error) {
+	query := `
+		SELECT kind, COUNT(*) FROM profile_views
+		WHERE profile_id = $1 AND day >= $2
+		GROUP BY kind
+	`
+	rows, err := r.db.QueryContext(ctx, query, profileID, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := map[ViewKind]int{}
+	for rows.Next() {
+		var kind ViewKind
+		var count int
+		if err := rows.Scan(&kind, &count); err != nil {
+			return nil, err
+		}
+		counts[kind] = count
+	}
+	return counts, rows.Err()
+}
+
+func (r *profileViewRepo) CountSearchViews(ctx context.Context, profileID string, since time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*) FROM profile_views
+		WHERE profile_id = $1 AND day >= $2 AND kind = $3 AND source = $4
+	`
+	var count int
+	err := r.db.QueryRowContext(ctx, query, profileID, since, ViewPage, SourceSearch).Scan(&count)
+	return count, err
+}
+
+func (r *profileViewRepo) DailyViews(ctx context.Context, profileID string, since time.Time) (map[string]int, error) {
+	query := `
+		SELECT to_char(day, 'YYYY-MM-DD'), COUNT(*) FROM profile_views
+		WHERE profile_id = $1 AND day >= $2 AND kind = $3
+		GROUP BY day
+	`
+	rows, err := r.db.QueryContext(ctx, query, profileID, since, ViewPage)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := map[string]int{}
+	for rows.Next() {
+		var day string
+		var count int
+		if err := rows.Scan(&day, &count); err != nil {
+			return nil, err
+		}
+		counts[day] = count
+	}
+	return counts, rows.Err()
+}
+
+func (r *profileViewRepo) TopReferrers(ctx context.Context, profileID string, since time.Time, limit int) ([]ReferrerCount, error) {
+	query := `
+		SELECT referrer_host, COUNT(*) AS views FROM profile_views
+		WHERE profile_id = $1 AND day >= $2 AND kind = $3 AND source = $4
+		GROUP BY referrer_host
+		ORDER BY views DESC, referrer_host
+		LIMIT $5
+	`
+	rows, err := r.db.QueryContext(ctx, query, profileID, since, ViewPage, SourceReferral, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	referrers := []ReferrerCount{}
+	for rows.Next() {
+		var referrer ReferrerCount
+		if err := rows.Scan(&referrer.Host, &referrer.Views); err != nil {
+			return nil, err
+		}
+		referrers = append(referrers, referrer)
+	}
+	return referrers, rows.Err()
+}
@@ -0,0 +1,76 @@
+package portfolio
+
+import (
+	"context"
+	"time"
+)
+
+// RecordProfileView conta um acesso ao perfil publicado já carregado. Robôs e o
+// próprio dono não contam; o mesmo visitante conta uma vez por dia e por tipo.
+func (s *PortfolioService) RecordProfileView(ctx context.Context, profile *Profile, visit ProfileVisit) error {
+	if IsBotUserAgent(visit.UserAgent) || visit.ViewerUserID == profile.UserID {
+		return nil
+	}
+	if visit.At.IsZero() {
+		visit.At = time.Now()
+	}
+	if visit.Source == "" {
+		visit.Source = SourceDirect
+	}
+	if visit.Source != SourceReferral {
+		visit.ReferrerHost = ""
+	}
+
+	return s.views.RecordView(ctx, &ProfileView{
+		ProfileID:    profile.ID,
+		Kind:         visit.Kind,
+		Day:          ViewDay(visit.At),
+		VisitorHash:  visitorHash(s.visitorKey, visit),
+		Source:       visit.Source,
+		ReferrerHost: visit.ReferrerHost,
+		CreatedAt:    visit.At,
+	})
+}
+
+// GetProfileAnalytics agrega as visitas dos últimos dias de um portfólio do
+// usuário (profileID vazio usa o principal), incluindo o dia de hoje
+func (s *PortfolioService) GetProfileAnalytics(ctx context.Context, userID string, profileID string, days int) (*ProfileAnalytics, error) {
+	profile, err := s.findOwned(ctx, userID, profileID)
+	if err != nil {
+		return nil, err
+	}
+	if days <= 0 {
+		days = DefaultAnalyticsDays
+	}
+	if days > MaxAnalyticsDays {
+		days = MaxAnalyticsDays
+	}
+	since := ViewDay(time.Now()).AddDate(0, 0, -(days - 1))
+
+	counts, err := s.views.CountViews(ctx, profile.ID, since)
+	if err != nil {
+		return nil, err
+	}
+	fromSearch, err := s.views.CountSearchViews(ctx, profile.ID, since)
+	if err != nil {
+		return nil, err
+	}
+	daily, err := s.views.DailyViews(ctx, profile.ID, since)
+	if err != nil {
+		return nil, err
+	}
+	referrers, err := s.views.TopReferrers(ctx, profile.ID, since, maxTopReferrers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProfileAnalytics{
+		Days:       days,
+		Views:      counts[ViewPage],
+		Prints:     counts[ViewPrint],
+		Downloads:  counts[ViewPDF],
+		FromSearch: fromSearch,
+		Daily:      fillDailyViews(daily, since, days),
+		Referrers:  referrers,
+	}, nil
+}
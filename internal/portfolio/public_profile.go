@@ -0,0 +1,119 @@
+package portfolio
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// PublicProfile é a projeção somente leitura do perfil publicado servida pela
+// API pública. Não expõe dados de gestão do dono (nome interno do portfólio,
+// visibilidade, privacidade por campo, traduções brutas).
+type PublicProfile struct {
+	ID                 string         `json:"id"`
+	Slug               string         `json:"slug,omitempty"`
+	PublicPath         string         `json:"publicPath"`
+	Headline           string         `json:"headline"`
+	Bio                string         `json:"bio"`
+	Seniority          Seniority      `json:"seniority"`
+	YearsOfExp         int            `json:"yearsOfExperience"`
+	ComputedYearsOfExp float64        `json:"computedYearsOfExperience"`
+	OpenToWork         bool           `json:"openToWork"`
+	SalaryExpectation  float64        `json:"salaryExpectation,omitempty"`
+	Currency           string         `json:"currency,omitempty"`
+	ContractType       string         `json:"contractType,omitempty"`
+	Location           LocationType   `json:"location,omitempty"`
+	RemoteOnly         bool           `json:"remoteOnly"`
+	Skills             Skills         `json:"skills"`
+	SocialLinks        SocialLinks    `json:"socialLinks"`
+	Experiences        Experiences    `json:"experiences"`
+	Projects           Projects       `json:"projects"`
+	Educations         Educations     `json:"educations"`
+	Certifications     Certifications `json:"certifications"`
+	Languages          Languages      `json:"languages"`
+	Publications       Publications   `json:"publications"`
+	Locale             Locale         `json:"locale"`
+	AvailableLocales   []Locale       `json:"availableLocales"`
+	UpdatedAt          time.Time      `json:"updatedAt"`
+	PublishedAt        *time.Time     `json:"publishedAt"`
+	// Grupos de campos ocultados para quem fez a requisição
+	RedactedFields []string `json:"redactedFields,omitempty"`
+}
+
+// ToPublic monta a projeção pública de um perfil já redigido e localizado
+func (p *Profile) ToPublic() *PublicProfile {
+	return &PublicProfile{
+		ID:                 p.ID,
+		Slug:               p.Slug,
+		PublicPath:         p.PublicPath(),
+		Headline:           p.Headline,
+		Bio:                p.Bio,
+		Seniority:          p.Seniority,
+		YearsOfExp:         p.YearsOfExp,
+		ComputedYearsOfExp: p.ComputedYearsOfExp,
+		OpenToWork:         p.OpenToWork,
+		SalaryExpectation:  p.SalaryExpectation,
+		Currency:           p.Currency,
+		ContractType:       p.ContractType,
+		Location:           p.Location,
+		RemoteOnly:         p.RemoteOnly,
+		Skills:             p.Skills,
+		SocialLinks:        p.SocialLinks,
+		Experiences:        p.Experiences,
+		Projects:           p.Projects,
+		Educations:         p.Educations,
+		Certifications:     p.Certifications,
+		Languages:          p.Languages,
+		Publications:       p.Publications,
+		Locale:             p.Locale,
+		AvailableLocales:   p.AvailableLocales(),
+		UpdatedAt:          p.UpdatedAt,
+		PublishedAt:        p.PublishedAt,
+		RedactedFields:     p.RedactedFields,
+	}
+}
+
+// LastModified é a data da última mudança visível: a edição do conteúdo publicado
+// ou a própria publicação, truncada para a precisão do cabeçalho HTTP.
+func (p *PublicProfile) LastModified() time.Time {
+	modified := p.UpdatedAt
+	if p.PublishedAt != nil && p.PublishedAt.After(modified) {
+		modified = *p.PublishedAt
+	}
+	return modified.UTC().Truncate(time.Second)
+}
+
+// ETag identifica a representação pelo hash do próprio corpo JSON: além da
+// versão, do slug, do idioma e dos campos ocultos, cobre valores calculados na
+// hora, como os anos de experiência de quem tem um emprego atual.
+func (p *PublicProfile) ETag() string {
+	body, err := json.Marshal(p)
+	if err != nil {
+		body = []byte(fmt.Sprintf("%s|%d", p.ID, p.UpdatedAt.UnixNano()))
+	}
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// NotModified aplica as pré-condições condicionais de GET (RFC 9110): If-None-Match
+// tem precedência e, na ausência dele, vale If-Modified-Since.
+func NotModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for _, candidate := range strings.Split(inm, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == "*" || candidate == etag {
+				return true
+			}
+		}
+		return false
+	}
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" {
+		since, err := http.ParseTime(ims)
+		return err == nil && !lastModified.After(since)
+	}
+	return false
+}
@@ -2,9 +2,14 @@ package portfolio
 
 import (
 	"encoding/json"
+	"errors"
 	"log"
+	"mime"
 	"net/http"
+	"portfolio/internal/auth"
 	"portfolio/internal/jwt"
+	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
 )
@@ -29,14 +34,63 @@ func (module *PortfolioModule) RegisterRoutes() *mux.Router {
 	router.HandleFunc("/", module.jwtService.RequiredAutenticationMiddleware(module.updateProfile)).Methods("PUT")
 	router.HandleFunc("/", module.jwtService.RequiredAutenticationMiddleware(module.patchProfile)).Methods("PATCH")
 	router.HandleFunc("/", module.jwtService.RequiredAutenticationMiddleware(module.deleteProfile)).Methods("DELETE")
+	// Vários portfólios por usuário. As rotas acima operam no perfil principal
+	// (ou no informado em ?profile=); as abaixo recebem o ID na URL.
+	router.HandleFunc("/me/profiles", module.jwtService.RequiredAutenticationMiddleware(module.listMyProfiles)).Methods("GET")
+	router.HandleFunc("/me/profiles", module.jwtService.RequiredAutenticationMiddleware(module.createProfile)).Methods("POST")
+	router.HandleFunc("/me/profiles/{profile_id}", module.jwtService.RequiredAutenticationMiddleware(module.getMyProfile)).Methods("GET")
+	router.HandleFunc("/me/profiles/{profile_id}", module.jwtService.RequiredAutenticationMiddleware(module.updateProfile)).Methods("PUT")
+	router.HandleFunc("/me/profiles/{profile_id}", module.jwtService.RequiredAutenticationMiddleware(module.patchProfile)).Methods("PATCH")
+	router.HandleFunc("/me/profiles/{profile_id}", module.jwtService.RequiredAutenticationMiddleware(module.deleteProfile)).Methods("DELETE")
+	router.HandleFunc("/me/profiles/{profile_id}/publish", module.jwtService.RequiredAutenticationMiddleware(module.publishProfile)).Methods("POST")
+	router.HandleFunc("/me/profiles/{profile_id}/primary", module.jwtService.RequiredAutenticationMiddleware(module.setPrimaryProfile)).Methods("POST")
+	router.HandleFunc("/me/profiles/{profile_id}/duplicate", module.jwtService.RequiredAutenticationMiddleware(module.duplicateProfile)).Methods("POST")
+	// Lixeira: portfólios excluídos podem ser restaurados por 30 dias
+	router.HandleFunc("/me/deleted", module.jwtService.RequiredAutenticationMiddleware(module.listDeletedProfiles)).Methods("GET")
+	router.HandleFunc("/me/profiles/{profile_id}/restore", module.jwtService.RequiredAutenticationMiddleware(module.restoreProfile)).Methods("POST")
+	router.HandleFunc("/me/export", module.jwtService.RequiredAutenticationMiddleware(module.exportProfile)).Methods("GET")
+	router.HandleFunc("/me/import", module.jwtService.RequiredAutenticationMiddleware(module.importProfile)).Methods("POST")
+	router.HandleFunc("/me/import/linkedin", module.jwtService.RequiredAutenticationMiddleware(module.importLinkedIn)).Methods("POST")
+	router.HandleFunc("/me/completeness", module.jwtService.RequiredAutenticationMiddleware(module.getCompleteness)).Methods("GET")
+	router.HandleFunc("/me/analytics", module.jwtService.RequiredAutenticationMiddleware(module.getAnalytics)).Methods("GET")
+	router.HandleFunc("/slug/check", module.jwtService.RequiredAutenticationMiddleware(module.checkSlug)).Methods("GET")
+	router.HandleFunc("/publish", module.jwtService.RequiredAutenticationMiddleware(module.publishProfile)).Methods("POST")
+	router.HandleFunc("/me/viewers", module.jwtService.RequiredAutenticationMiddleware(module.listApprovedViewers)).Methods("GET")
+	router.HandleFunc("/me/viewers", module.jwtService.RequiredAutenticationMiddleware(module.approveViewer)).Methods("POST")
+	router.HandleFunc("/me/viewers/{user_id}", module.jwtService.RequiredAutenticationMiddleware(module.revokeViewer)).Methods("DELETE")
+	// Endossos e recomendações. As rotas /me vêm antes de /{profile_id} para não serem capturadas por ela.
+	router.HandleFunc("/me/recommendations", module.jwtService.RequiredAutenticationMiddleware(module.listReceivedRecommendations)).Methods("GET")
+	router.HandleFunc("/me/recommendations/{recommendation_id}/approve", module.jwtService.RequiredAutenticationMiddleware(module.approveRecommendation)).Methods("POST")
+	router.HandleFunc("/me/recommendations/{recommendation_id}/reject", module.jwtService.RequiredAutenticationMiddleware(module.rejectRecommendation)).Methods("POST")
+	router.HandleFunc("/recommendations/{recommendation_id}", module.jwtService.RequiredAutenticationMiddleware(module.deleteRecommendation)).Methods("DELETE")
+	router.HandleFunc("/endorsements/{endorsement_id}", module.jwtService.RequiredAutenticationMiddleware(module.withdrawEndorsement)).Methods("DELETE")
+	router.HandleFunc("/{profile_id}/endorsements", module.jwtService.OptionalAutenticationMiddleware(module.listEndorsements)).Methods("GET")
+	router.HandleFunc("/{profile_id}/endorsements", module.jwtService.RequiredAutenticationMiddleware(module.endorseSkill)).Methods("POST")
+	router.HandleFunc("/{profile_id}/recommendations", module.jwtService.OptionalAutenticationMiddleware(module.listRecommendations)).Methods("GET")
+	router.HandleFunc("/{profile_id}/recommendations", module.jwtService.RequiredAutenticationMiddleware(module.recommendProfile)).Methods("POST")
+	// API pública somente leitura: versão publicada do perfil, por ID ou por slug
+	router.HandleFunc("/u/{slug}", module.jwtService.OptionalAutenticationMiddleware(module.getProfileBySlug)).Methods("GET")
+	router.HandleFunc("/{profile_id}", module.jwtService.OptionalAutenticationMiddleware(module.getProfile)).Methods("GET")
 
 	return router
 }
 
+// acceptPatch anuncia os formatos aceitos em PATCH do perfil (RFC 5789)
+const acceptPatch = "application/json, " + MergePatchContentType + ", " + JSONPatchContentType
+
+// profileIDFromRequest retorna o perfil alvo da requisição: o ID da rota
+// /me/profiles/{profile_id}, o parâmetro ?profile= ou vazio (perfil principal)
+func profileIDFromRequest(r *http.Request) string {
+	if id := mux.Vars(r)["profile_id"]; id != "" {
+		return id
+	}
+	return r.URL.Query().Get("profile")
+}
+
 func (module *PortfolioModule) getMyProfile(w http.ResponseWriter, r *http.Request) {
 	user := jwt.GetUserCurrentUser(r.Context())
 	userId := user.ID
-	profile, err := module.service.GetMyProfile(r.Context(), userId)
+	profile, err := module.service.GetMyProfileByID(r.Context(), userId, profileIDFromRequest(r))
 
 	if err != nil {
 		if err == ErrProfileNotFound {
@@ -49,28 +103,84 @@ func (module *PortfolioModule) getMyProfile(w http.ResponseWriter, r *http.Reque
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", profile.ETag())
+	w.Header().Set("Accept-Patch", acceptPatch)
 	json.NewEncoder(w).Encode(profile)
 }
 
 func (module *PortfolioModule) getProfile(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-	profileID := vars["portfolioId"]
+	module.writePublicProfile(w, r, mux.Vars(r)["profile_id"])
+}
+
+// getProfileBySlug redireciona slugs antigos para o atual, como a página pública
+func (module *PortfolioModule) getProfileBySlug(w http.ResponseWriter, r *http.Request) {
+	slug := mux.Vars(r)["slug"]
+
+	profileID, currentSlug, err := module.service.ResolveSlug(r.Context(), slug)
+	if err != nil {
+		if errors.Is(err, ErrProfileNotFound) {
+			http.Error(w, "Profile not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("ResolveSlug error: %v", err)
+		http.Error(w, "Failed to get profile", http.StatusInternalServerError)
+		return
+	}
+
+	if currentSlug != slug {
+		target := "/portfolio/" + profileID
+		if currentSlug != "" {
+			target = "/portfolio/u/" + currentSlug
+		}
+		if r.URL.RawQuery != "" {
+			target += "?" + r.URL.RawQuery
+		}
+		http.Redirect(w, r, target, http.StatusMovedPermanently)
+		return
+	}
+	module.writePublicProfile(w, r, profileID)
+}
 
-	profile, err := module.service.GetProfile(r.Context(), profileID)
+// writePublicProfile responde a projeção pública da versão publicada do perfil,
+// com ETag/Last-Modified para que clientes revalidem sem baixar tudo de novo
+func (module *PortfolioModule) writePublicProfile(w http.ResponseWriter, r *http.Request, profileID string) {
+	viewer := jwt.GetUserCurrentUser(r.Context())
+
+	profile, err := module.service.GetVisibleProfile(r.Context(), profileID, viewer.ID)
 
 	if err != nil {
 		if err == ErrProfileNotFound {
 			http.Error(w, "Profile not found", http.StatusNotFound)
 			return
 		}
+		if err == ErrProfileRestricted {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
 		log.Printf("GetProfile error: %v", err)
 		http.Error(w, "Failed to get profile", http.StatusInternalServerError)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(profile)
+	locale := NegotiateLocale(r.URL.Query().Get("lang"), r.Header.Get("Accept-Language"), profile.AvailableLocales(), profile.ContentLocale())
+	public := profile.Localize(locale).ToPublic()
+	etag := public.ETag()
+	lastModified := public.LastModified()
+
+	// O conteúdo depende de quem está vendo (campos ocultos), então o cache é privado
+	w.Header().Set("Cache-Control", "private, no-cache")
+	// A sessão do navegador vem no cookie access_token, que também muda a redação
+	w.Header().Set("Vary", "Accept-Language, Authorization, Cookie")
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Last-Modified", lastModified.Format(http.TimeFormat))
+	w.Header().Set("Content-Language", string(public.Locale))
+	if NotModified(r, etag, lastModified) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
 
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(public)
 }
 
 func (module *PortfolioModule) createProfile(w http.ResponseWriter, r *http.Request) {
@@ -86,10 +196,13 @@ func (module *PortfolioModule) createProfile(w http.ResponseWriter, r *http.Requ
 
 	profile, err := module.service.CreateProfile(r.Context(), userID, input)
 	if err != nil {
-		if err == ErrProfileAlreadyExists {
+		if err == ErrProfileLimitReached {
 			http.Error(w, err.Error(), http.StatusConflict)
 			return
 		}
+		if writeValidationError(w, err) || writeSlugError(w, err) {
+			return
+		}
 		log.Printf("CreateProfile error: %v", err)
 		http.Error(w, "Failed to create profile", http.StatusInternalServerError)
 		return
@@ -104,24 +217,38 @@ func (module *PortfolioModule) updateProfile(w http.ResponseWriter, r *http.Requ
 	user := jwt.GetUserCurrentUser(r.Context())
 	userID := user.ID
 
+	expectedVersion, ok := ParseIfMatch(r.Header.Get("If-Match"))
+	if !ok {
+		http.Error(w, ErrVersionConflict.Error(), http.StatusPreconditionFailed)
+		return
+	}
+
 	var input SaveProfileInput
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
 		http.Error(w, "Invalid request payload", http.StatusBadRequest)
 		return
 	}
 
-	profile, err := module.service.UpdateProfile(r.Context(), userID, input)
+	profile, err := module.service.UpdateProfile(r.Context(), userID, profileIDFromRequest(r), expectedVersion, input)
 	if err != nil {
 		if err == ErrProfileNotFound {
 			http.Error(w, "Profile not found", http.StatusNotFound)
 			return
 		}
+		if err == ErrVersionConflict {
+			http.Error(w, err.Error(), http.StatusPreconditionFailed)
+			return
+		}
+		if writeValidationError(w, err) || writeSlugError(w, err) {
+			return
+		}
 		log.Printf("UpdateProfile error: %v", err)
 		http.Error(w, "Failed to update profile", http.StatusInternalServerError)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", profile.ETag())
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(profile)
 }
@@ -130,33 +257,459 @@ func (module *PortfolioModule) patchProfile(w http.ResponseWriter, r *http.Reque
 	user := jwt.GetUserCurrentUser(r.Context())
 	userID := user.ID
 
-	var input PatchProfileDTO
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+	expectedVersion, ok := ParseIfMatch(r.Header.Get("If-Match"))
+	if !ok {
+		http.Error(w, ErrVersionConflict.Error(), http.StatusPreconditionFailed)
 		return
 	}
 
-	profile, err := module.service.PatchProfile(r.Context(), userID, input)
+	var profile *Profile
+	var err error
+	// Além do PatchProfileDTO (application/json), aceita patches sobre o documento do perfil
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case MergePatchContentType:
+		var patch json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+			http.Error(w, "Invalid request payload", http.StatusBadRequest)
+			return
+		}
+		profile, err = module.service.PatchProfileDocument(r.Context(), userID, profileIDFromRequest(r), expectedVersion, MergePatch(patch))
+	case JSONPatchContentType:
+		var patch JSONPatch
+		if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+			http.Error(w, "Invalid request payload", http.StatusBadRequest)
+			return
+		}
+		profile, err = module.service.PatchProfileDocument(r.Context(), userID, profileIDFromRequest(r), expectedVersion, patch)
+	default:
+		var input PatchProfileDTO
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			http.Error(w, "Invalid request payload", http.StatusBadRequest)
+			return
+		}
+		profile, err = module.service.PatchProfile(r.Context(), userID, profileIDFromRequest(r), expectedVersion, input)
+	}
 	if err != nil {
 		if err == ErrProfileNotFound {
 			http.Error(w, "Profile not found", http.StatusNotFound)
 			return
 		}
+		if err == ErrVersionConflict {
+			http.Error(w, err.Error(), http.StatusPreconditionFailed)
+			return
+		}
+		if errors.Is(err, ErrInvalidPatch) {
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		if writeValidationError(w, err) || writeSlugError(w, err) {
+			return
+		}
 		log.Printf("UpdateProfile error: %v", err)
 		http.Error(w, "Failed to update profile", http.StatusInternalServerError)
 		return
 	}
 
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", profile.ETag())
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(profile)
+}
+
+func (module *PortfolioModule) publishProfile(w http.ResponseWriter, r *http.Request) {
+	user := jwt.GetUserCurrentUser(r.Context())
+	userID := user.ID
+
+	profile, err := module.service.PublishProfile(r.Context(), userID, profileIDFromRequest(r))
+	if err != nil {
+		if err == ErrProfileNotFound {
+			http.Error(w, "Profile not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("PublishProfile error: %v", err)
+		http.Error(w, "Failed to publish profile", http.StatusInternalServerError)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(profile)
 }
 
+func (module *PortfolioModule) getCompleteness(w http.ResponseWriter, r *http.Request) {
+	user := jwt.GetUserCurrentUser(r.Context())
+
+	completeness, err := module.service.GetCompleteness(r.Context(), user.ID, profileIDFromRequest(r))
+	if err != nil {
+		if err == ErrProfileNotFound {
+			http.Error(w, "Profile not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("GetCompleteness error: %v", err)
+		http.Error(w, "Failed to compute completeness", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(completeness)
+}
+
+func (module *PortfolioModule) getAnalytics(w http.ResponseWriter, r *http.Request) {
+	user := jwt.GetUserCurrentUser(r.Context())
+
+	days := DefaultAnalyticsDays
+	if raw := r.URL.Query().Get("days"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > MaxAnalyticsDays {
+			http.Error(w, "days must be between 1 and 365", http.StatusBadRequest)
+			return
+		}
+		days = parsed
+	}
+
+	analytics, err := module.service.GetProfileAnalytics(r.Context(), user.ID, profileIDFromRequest(r), days)
+	if err != nil {
+		if err == ErrProfileNotFound {
+			http.Error(w, "Profile not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("GetProfileAnalytics error: %v", err)
+		http.Error(w, "Failed to load profile analytics", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(analytics)
+}
+
+func (module *PortfolioModule) exportProfile(w http.ResponseWriter, r *http.Request) {
+	user := jwt.GetUserCurrentUser(r.Context())
+
+	format := r.URL.Query().Get("format")
+	if format != "jsonresume" {
+		http.Error(w, ErrUnsupportedExportFormat.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resume, err := module.service.ExportJSONResume(r.Context(), user.ID, profileIDFromRequest(r))
+	if err != nil {
+		if err == ErrProfileNotFound {
+			http.Error(w, "Profile not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("ExportProfile error: %v", err)
+		http.Error(w, "Failed to export profile", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", `attachment; filename="resume.json"`)
+	json.NewEncoder(w).Encode(resume)
+}
+
+// importProfile recebe um documento JSON Resume. Com ?dry_run=true apenas
+// retorna as alterações que seriam feitas no rascunho.
+func (module *PortfolioModule) importProfile(w http.ResponseWriter, r *http.Request) {
+	user := jwt.GetUserCurrentUser(r.Context())
+
+	var resume JSONResume
+	if err := json.NewDecoder(r.Body).Decode(&resume); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+	dryRun := r.URL.Query().Get("dry_run") == "true"
+	// If-Match liga a importação à versão vista no dry run
+	expectedVersion, ok := ParseIfMatch(r.Header.Get("If-Match"))
+	if !ok {
+		http.Error(w, ErrVersionConflict.Error(), http.StatusPreconditionFailed)
+		return
+	}
+
+	result, err := module.service.ImportJSONResume(r.Context(), user.ID, profileIDFromRequest(r), expectedVersion, resume, dryRun)
+	if err != nil {
+		if err == ErrProfileNotFound {
+			http.Error(w, "Profile not found", http.StatusNotFound)
+			return
+		}
+		if err == ErrVersionConflict {
+			http.Error(w, err.Error(), http.StatusPreconditionFailed)
+			return
+		}
+		if writeValidationError(w, err) {
+			return
+		}
+		log.Printf("ImportProfile error: %v", err)
+		http.Error(w, "Failed to import profile", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	// Perfil que ainda não existe (dry run de criação) não tem versão
+	if !result.Created || !dryRun {
+		w.Header().Set("ETag", result.Profile.ETag())
+	}
+	if result.Created && !dryRun {
+		w.WriteHeader(http.StatusCreated)
+	}
+	json.NewEncoder(w).Encode(result)
+}
+
+// importLinkedIn recebe o ZIP exportado pelo LinkedIn no campo "file" (multipart).
+// Com ?dry_run=true apenas retorna o que seria mesclado ao rascunho.
+func (module *PortfolioModule) importLinkedIn(w http.ResponseWriter, r *http.Request) {
+	user := jwt.GetUserCurrentUser(r.Context())
+
+	r.Body = http.MaxBytesReader(w, r.Body, MaxLinkedInExportSize)
+	if err := r.ParseMultipartForm(MaxLinkedInExportSize); err != nil {
+		http.Error(w, "File too large or invalid form", http.StatusBadRequest)
+		return
+	}
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "Missing file", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+	dryRun := r.URL.Query().Get("dry_run") == "true"
+	expectedVersion, ok := ParseIfMatch(r.Header.Get("If-Match"))
+	if !ok {
+		http.Error(w, ErrVersionConflict.Error(), http.StatusPreconditionFailed)
+		return
+	}
+
+	result, err := module.service.ImportLinkedIn(r.Context(), user.ID, profileIDFromRequest(r), expectedVersion, file, header.Size, dryRun)
+	if err != nil {
+		if err == ErrProfileNotFound {
+			http.Error(w, "Profile not found", http.StatusNotFound)
+			return
+		}
+		if err == ErrVersionConflict {
+			http.Error(w, err.Error(), http.StatusPreconditionFailed)
+			return
+		}
+		if errors.Is(err, ErrInvalidImportFile) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if writeValidationError(w, err) {
+			return
+		}
+		log.Printf("ImportLinkedIn error: %v", err)
+		http.Error(w, "Failed to import LinkedIn data", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !result.Created || !dryRun {
+		w.Header().Set("ETag", result.Profile.ETag())
+	}
+	if result.Created && !dryRun {
+		w.WriteHeader(http.StatusCreated)
+	}
+	json.NewEncoder(w).Encode(result)
+}
+
+func (module *PortfolioModule) listMyProfiles(w http.ResponseWriter, r *http.Request) {
+	user := jwt.GetUserCurrentUser(r.Context())
+
+	profiles, err := module.service.ListMyProfiles(r.Context(), user.ID)
+	if err != nil {
+		log.Printf("ListMyProfiles error: %v", err)
+		http.Error(w, "Failed to list profiles", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(profiles)
+}
+
+func (module *PortfolioModule) setPrimaryProfile(w http.ResponseWriter, r *http.Request) {
+	user := jwt.GetUserCurrentUser(r.Context())
+
+	profile, err := module.service.SetPrimaryProfile(r.Context(), user.ID, profileIDFromRequest(r))
+	if err != nil {
+		if err == ErrProfileNotFound {
+			http.Error(w, "Profile not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("SetPrimaryProfile error: %v", err)
+		http.Error(w, "Failed to set primary profile", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(profile)
+}
+
+type duplicateProfileInput struct {
+	Name string `json:"name"`
+}
+
+func (module *PortfolioModule) duplicateProfile(w http.ResponseWriter, r *http.Request) {
+	user := jwt.GetUserCurrentUser(r.Context())
+
+	var input duplicateProfileInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	profile, err := module.service.DuplicateProfile(r.Context(), user.ID, profileIDFromRequest(r), input.Name)
+	if err != nil {
+		if writeValidationError(w, err) {
+			return
+		}
+		switch err {
+		case ErrProfileNotFound:
+			http.Error(w, "Profile not found", http.StatusNotFound)
+		case ErrProfileLimitReached:
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			log.Printf("DuplicateProfile error: %v", err)
+			http.Error(w, "Failed to duplicate profile", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(profile)
+}
+
+type slugAvailability struct {
+	Slug      string `json:"slug"`
+	Available bool   `json:"available"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+func (module *PortfolioModule) checkSlug(w http.ResponseWriter, r *http.Request) {
+	user := jwt.GetUserCurrentUser(r.Context())
+	slug := NormalizeSlug(r.URL.Query().Get("slug"))
+
+	result := slugAvailability{Slug: slug, Available: true}
+	if err := module.service.CheckSlugAvailability(r.Context(), user.ID, profileIDFromRequest(r), slug); err != nil {
+		if !errors.Is(err, ErrInvalidSlug) && !errors.Is(err, ErrSlugReserved) && !errors.Is(err, ErrSlugTaken) {
+			log.Printf("CheckSlug error: %v", err)
+			http.Error(w, "Failed to check slug", http.StatusInternalServerError)
+			return
+		}
+		result.Available = false
+		result.Reason = err.Error()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// validationErrorResponse é o corpo das respostas 422
+type validationErrorResponse struct {
+	Error  string           `json:"error"`
+	Errors ValidationErrors `json:"errors"`
+}
+
+// writeValidationError responde 422 com a lista de campos inválidos e indica se o erro foi tratado
+func writeValidationError(w http.ResponseWriter, err error) bool {
+	var verrs ValidationErrors
+	if !errors.As(err, &verrs) {
+		return false
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	json.NewEncoder(w).Encode(validationErrorResponse{
+		Error:  ErrInvalidProfileData.Error(),
+		Errors: verrs,
+	})
+	return true
+}
+
+// writeSlugError responde erros de slug e indica se o erro foi tratado
+func writeSlugError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, ErrSlugTaken):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, ErrInvalidSlug), errors.Is(err, ErrSlugReserved):
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+	default:
+		return false
+	}
+	return true
+}
+
+type approveViewerInput struct {
+	Email string `json:"email"`
+}
+
+func (module *PortfolioModule) listApprovedViewers(w http.ResponseWriter, r *http.Request) {
+	user := jwt.GetUserCurrentUser(r.Context())
+
+	viewers, err := module.service.ListApprovedViewers(r.Context(), user.ID, profileIDFromRequest(r))
+	if err != nil {
+		if err == ErrProfileNotFound {
+			http.Error(w, "Profile not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("ListApprovedViewers error: %v", err)
+		http.Error(w, "Failed to list approved viewers", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(viewers)
+}
+
+func (module *PortfolioModule) approveViewer(w http.ResponseWriter, r *http.Request) {
+	user := jwt.GetUserCurrentUser(r.Context())
+
+	var input approveViewerInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil || input.Email == "" {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	viewer, err := module.service.ApproveViewer(r.Context(), user.ID, profileIDFromRequest(r), input.Email)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrProfileNotFound):
+			http.Error(w, "Profile not found", http.StatusNotFound)
+		case errors.Is(err, auth.ErrUserNotFound):
+			http.Error(w, "User not found", http.StatusNotFound)
+		case errors.Is(err, ErrCannotApproveSelf):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			log.Printf("ApproveViewer error: %v", err)
+			http.Error(w, "Failed to approve viewer", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(viewer)
+}
+
+func (module *PortfolioModule) revokeViewer(w http.ResponseWriter, r *http.Request) {
+	user := jwt.GetUserCurrentUser(r.Context())
+	viewerUserID := mux.Vars(r)["user_id"]
+
+	if err := module.service.RevokeViewer(r.Context(), user.ID, profileIDFromRequest(r), viewerUserID); err != nil {
+		if err == ErrProfileNotFound {
+			http.Error(w, "Profile not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("RevokeViewer error: %v", err)
+		http.Error(w, "Failed to revoke viewer", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (module *PortfolioModule) deleteProfile(w http.ResponseWriter, r *http.Request) {
 	user := jwt.GetUserCurrentUser(r.Context())
 	userID := user.ID
 
-	if err := module.service.DeleteProfile(r.Context(), userID); err != nil {
+	if err := module.service.DeleteProfile(r.Context(), userID, profileIDFromRequest(r)); err != nil {
 		if err == ErrProfileNotFound {
 			http.Error(w, "Profile not found", http.StatusNotFound)
 			return
@@ -168,3 +721,236 @@ func (module *PortfolioModule) deleteProfile(w http.ResponseWriter, r *http.Requ
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+func (module *PortfolioModule) listDeletedProfiles(w http.ResponseWriter, r *http.Request) {
+	user := jwt.GetUserCurrentUser(r.Context())
+
+	profiles, err := module.service.ListDeletedProfiles(r.Context(), user.ID)
+	if err != nil {
+		log.Printf("ListDeletedProfiles error: %v", err)
+		http.Error(w, "Failed to list deleted profiles", http.StatusInternalServerError)
+		return
+	}
+
+	type deletedProfile struct {
+		*Profile
+		RestoreDeadline time.Time `json:"restoreDeadline"`
+	}
+	response := make([]deletedProfile, 0, len(profiles))
+	for _, p := range profiles {
+		response = append(response, deletedProfile{Profile: p, RestoreDeadline: p.RestoreDeadline()})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (module *PortfolioModule) restoreProfile(w http.ResponseWriter, r *http.Request) {
+	user := jwt.GetUserCurrentUser(r.Context())
+
+	profile, err := module.service.RestoreProfile(r.Context(), user.ID, profileIDFromRequest(r))
+	if err != nil {
+		switch err {
+		case ErrProfileNotFound:
+			http.Error(w, "Profile not found or restore window expired", http.StatusNotFound)
+		case ErrProfileLimitReached:
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			log.Printf("RestoreProfile error: %v", err)
+			http.Error(w, "Failed to restore profile", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", profile.ETag())
+	json.NewEncoder(w).Encode(profile)
+}
+
+// writePeerFeedbackError responde os erros de endossos e recomendações e indica se o erro foi tratado
+func writePeerFeedbackError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, ErrProfileNotFound):
+		http.Error(w, "Profile not found", http.StatusNotFound)
+	case errors.Is(err, ErrProfileRestricted):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, ErrEndorsementNotFound), errors.Is(err, ErrRecommendationNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrRecommendationExists):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, ErrCannotEndorseSelf):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, ErrSkillNotOnProfile), errors.Is(err, ErrNoSharedExperience):
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+	case errors.Is(err, ErrInvalidModerationStatus):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		return writeValidationError(w, err)
+	}
+	return true
+}
+
+type endorseSkillInput struct {
+	Skill string `json:"skill"`
+}
+
+func (module *PortfolioModule) listEndorsements(w http.ResponseWriter, r *http.Request) {
+	viewer := jwt.GetUserCurrentUser(r.Context())
+
+	endorsements, err := module.service.ListEndorsements(r.Context(), mux.Vars(r)["profile_id"], viewer.ID)
+	if err != nil {
+		if writePeerFeedbackError(w, err) {
+			return
+		}
+		log.Printf("ListEndorsements error: %v", err)
+		http.Error(w, "Failed to list endorsements", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(endorsements)
+}
+
+func (module *PortfolioModule) endorseSkill(w http.ResponseWriter, r *http.Request) {
+	user := jwt.GetUserCurrentUser(r.Context())
+
+	var input endorseSkillInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil || input.Skill == "" {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	summary, err := module.service.EndorseSkill(r.Context(), user.ID, mux.Vars(r)["profile_id"], input.Skill)
+	if err != nil {
+		if writePeerFeedbackError(w, err) {
+			return
+		}
+		log.Printf("EndorseSkill error: %v", err)
+		http.Error(w, "Failed to endorse skill", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(summary)
+}
+
+func (module *PortfolioModule) withdrawEndorsement(w http.ResponseWriter, r *http.Request) {
+	user := jwt.GetUserCurrentUser(r.Context())
+
+	if err := module.service.WithdrawEndorsement(r.Context(), user.ID, mux.Vars(r)["endorsement_id"]); err != nil {
+		if writePeerFeedbackError(w, err) {
+			return
+		}
+		log.Printf("WithdrawEndorsement error: %v", err)
+		http.Error(w, "Failed to withdraw endorsement", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (module *PortfolioModule) listRecommendations(w http.ResponseWriter, r *http.Request) {
+	viewer := jwt.GetUserCurrentUser(r.Context())
+
+	recommendations, err := module.service.ListRecommendations(r.Context(), mux.Vars(r)["profile_id"], viewer.ID)
+	if err != nil {
+		if writePeerFeedbackError(w, err) {
+			return
+		}
+		log.Printf("ListRecommendations error: %v", err)
+		http.Error(w, "Failed to list recommendations", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(recommendations)
+}
+
+func (module *PortfolioModule) recommendProfile(w http.ResponseWriter, r *http.Request) {
+	user := jwt.GetUserCurrentUser(r.Context())
+
+	var input RecommendationInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	recommendation, err := module.service.RecommendProfile(r.Context(), user.ID, mux.Vars(r)["profile_id"], input)
+	if err != nil {
+		if writePeerFeedbackError(w, err) {
+			return
+		}
+		log.Printf("RecommendProfile error: %v", err)
+		http.Error(w, "Failed to create recommendation", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(recommendation)
+}
+
+// listReceivedRecommendations aceita ?status=PENDING|APPROVED|REJECTED e ?profile=
+func (module *PortfolioModule) listReceivedRecommendations(w http.ResponseWriter, r *http.Request) {
+	user := jwt.GetUserCurrentUser(r.Context())
+
+	status := RecommendationStatus(r.URL.Query().Get("status"))
+	if status != "" && !status.IsValid() {
+		http.Error(w, "Invalid status", http.StatusBadRequest)
+		return
+	}
+
+	recommendations, err := module.service.ListReceivedRecommendations(r.Context(), user.ID, profileIDFromRequest(r), status)
+	if err != nil {
+		if writePeerFeedbackError(w, err) {
+			return
+		}
+		log.Printf("ListReceivedRecommendations error: %v", err)
+		http.Error(w, "Failed to list recommendations", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(recommendations)
+}
+
+func (module *PortfolioModule) approveRecommendation(w http.ResponseWriter, r *http.Request) {
+	module.moderateRecommendation(w, r, RecommendationApproved)
+}
+
+func (module *PortfolioModule) rejectRecommendation(w http.ResponseWriter, r *http.Request) {
+	module.moderateRecommendation(w, r, RecommendationRejected)
+}
+
+func (module *PortfolioModule) moderateRecommendation(w http.ResponseWriter, r *http.Request, status RecommendationStatus) {
+	user := jwt.GetUserCurrentUser(r.Context())
+
+	recommendation, err := module.service.ModerateRecommendation(r.Context(), user.ID, mux.Vars(r)["recommendation_id"], status)
+	if err != nil {
+		if writePeerFeedbackError(w, err) {
+			return
+		}
+		log.Printf("ModerateRecommendation error: %v", err)
+		http.Error(w, "Failed to moderate recommendation", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(recommendation)
+}
+
+func (module *PortfolioModule) deleteRecommendation(w http.ResponseWriter, r *http.Request) {
+	user := jwt.GetUserCurrentUser(r.Context())
+
+	if err := module.service.DeleteRecommendation(r.Context(), user.ID, mux.Vars(r)["recommendation_id"]); err != nil {
+		if writePeerFeedbackError(w, err) {
+			return
+		}
+		log.Printf("DeleteRecommendation error: %v", err)
+		http.Error(w, "Failed to delete recommendation", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
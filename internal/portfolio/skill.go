@@ -0,0 +1,188 @@
+package portfolio
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SkillLevel é a proficiência declarada em uma habilidade
+type SkillLevel string
+
+const (
+	SkillBeginner     SkillLevel = "BEGINNER"
+	SkillIntermediate SkillLevel = "INTERMEDIATE"
+	SkillAdvanced     SkillLevel = "ADVANCED"
+	SkillExpert       SkillLevel = "EXPERT"
+)
+
+// SkillLevels lista os níveis do menor para o maior
+var SkillLevels = []SkillLevel{SkillBeginner, SkillIntermediate, SkillAdvanced, SkillExpert}
+
+func (l SkillLevel) Int() int {
+	for i, level := range SkillLevels {
+		if level == l {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func (l SkillLevel) IsValid() bool {
+	return l.Int() != 0
+}
+
+// Label retorna a descrição do nível exibida no portfólio
+func (l SkillLevel) Label() string {
+	switch l {
+	case SkillBeginner:
+		return "Iniciante"
+	case SkillIntermediate:
+		return "Intermediário"
+	case SkillAdvanced:
+		return "Avançado"
+	case SkillExpert:
+		return "Especialista"
+	default:
+		return string(l)
+	}
+}
+
+// ParseSkillLevel reconhece o nível em textos livres como "Advanced" ou
+// "Master" (usado nas importações)
+func ParseSkillLevel(text string) (SkillLevel, bool) {
+	text = strings.ToUpper(strings.TrimSpace(text))
+	if level := SkillLevel(text); level.IsValid() {
+		return level, true
+	}
+	switch {
+	case strings.Contains(text, "EXPERT"), strings.Contains(text, "MASTER"), strings.Contains(text, "ESPECIALISTA"):
+		return SkillExpert, true
+	case strings.Contains(text, "ADVANCED"), strings.Contains(text, "AVANÇ"), strings.Contains(text, "SENIOR"):
+		return SkillAdvanced, true
+	case strings.Contains(text, "INTERMEDI"):
+		return SkillIntermediate, true
+	case strings.Contains(text, "BEGINNER"), strings.Contains(text, "INICIANTE"), strings.Contains(text, "BASIC"), strings.Contains(text, "BÁSIC"):
+		return SkillBeginner, true
+	}
+	return "", false
+}
+
+// Skill é uma habilidade com proficiência e tempo de uso opcionais
+type Skill struct {
+	Name  string     `json:"name"`
+	Level SkillLevel `json:"level,omitempty"`
+	// Years é o tempo de experiência na habilidade, em anos completos
+	Years    int        `json:"years,omitempty"`
+	LastUsed *time.Time `json:"lastUsed,omitempty"`
+}
+
+// UnmarshalJSON aceita tanto o formato estruturado quanto o nome puro, usado
+// pelos perfis salvos antes dos níveis e por clientes antigos da API
+func (s *Skill) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*s = Skill{Name: name}
+		return nil
+	}
+	type skill Skill
+	var structured skill
+	if err := json.Unmarshal(data, &structured); err != nil {
+		return err
+	}
+	*s = Skill(structured)
+	return nil
+}
+
+// Summary descreve nível e tempo de uso, ex: "Avançado, 5 anos"
+func (s Skill) Summary() string {
+	var parts []string
+	if s.Level.IsValid() {
+		parts = append(parts, s.Level.Label())
+	}
+	switch {
+	case s.Years == 1:
+		parts = append(parts, "1 ano")
+	case s.Years > 1:
+		parts = append(parts, strconv.Itoa(s.Years)+" anos")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Label retorna o nome seguido do resumo, ex: "Go (Avançado, 5 anos)"
+func (s Skill) Label() string {
+	if summary := s.Summary(); summary != "" {
+		return s.Name + " (" + summary + ")"
+	}
+	return s.Name
+}
+
+type Skills []Skill
+
+func (s Skills) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
+func (s *Skills) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, &s)
+}
+
+// Names retorna apenas os nomes das habilidades
+func (s Skills) Names() []string {
+	names := make([]string, len(s))
+	for i, skill := range s {
+		names[i] = skill.Name
+	}
+	return names
+}
+
+// Contains indica se a habilidade já está na lista, ignorando maiúsculas
+func (s Skills) Contains(name string) bool {
+	for _, skill := range s {
+		if strings.EqualFold(strings.TrimSpace(skill.Name), strings.TrimSpace(name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// SkillLevelTerm é o valor indexado que representa "nome com nível mínimo level"
+func SkillLevelTerm(name string, level SkillLevel) string {
+	return strings.ToLower(strings.TrimSpace(name)) + ":" + string(level)
+}
+
+// SkillYearsTerm é o valor indexado que representa "nome com pelo menos years anos"
+func SkillYearsTerm(name string, years int) string {
+	return strings.ToLower(strings.TrimSpace(name)) + ":" + strconv.Itoa(years)
+}
+
+// LevelSearchTerms gera os valores indexados para o filtro de nível. Cada
+// habilidade entra com todos os níveis até o declarado, de modo que o filtro
+// skillLevels = 'go:ADVANCED' significa "Go avançado ou superior".
+func (s Skills) LevelSearchTerms() []string {
+	terms := make([]string, 0)
+	for _, skill := range s {
+		for _, level := range SkillLevels[:skill.Level.Int()] {
+			terms = append(terms, SkillLevelTerm(skill.Name, level))
+		}
+	}
+	return terms
+}
+
+// YearsSearchTerms gera os valores indexados para o filtro de tempo de uso,
+// com a mesma lógica de LevelSearchTerms ("kubernetes:1" ... "kubernetes:3")
+func (s Skills) YearsSearchTerms() []string {
+	terms := make([]string, 0)
+	for _, skill := range s {
+		for years := 1; years <= skill.Years && years <= maxSkillYears; years++ {
+			terms = append(terms, SkillYearsTerm(skill.Name, years))
+		}
+	}
+	return terms
+}
@@ -0,0 +1,45 @@
+package portfolio
+
+import (
+	"regexp"
+	"strings"
+)
+
+// slugPattern aceita de 3 a 40 caracteres: letras minúsculas, números e hífens,
+// sem começar ou terminar com hífen
+var slugPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{1,38}[a-z0-9]$`)
+
+// reservedSlugs não podem ser escolhidos por conflitarem com rotas ou por
+// poderem ser confundidos com páginas oficiais
+var reservedSlugs = map[string]struct{}{
+	"about": {}, "admin": {}, "api": {}, "app": {}, "assets": {}, "auth": {},
+	"config": {}, "dashboard": {}, "edit": {}, "help": {}, "login": {}, "logout": {},
+	"me": {}, "media": {}, "new": {}, "portfolio": {}, "preview": {}, "print": {},
+	"privacy": {}, "profile": {}, "publish": {}, "root": {}, "search": {}, "settings": {},
+	"signup": {}, "static": {}, "support": {}, "sync": {}, "terms": {}, "www": {},
+}
+
+// NormalizeSlug remove espaços e converte para minúsculas
+func NormalizeSlug(slug string) string {
+	return strings.ToLower(strings.TrimSpace(slug))
+}
+
+// ValidateSlug verifica o formato e a lista de palavras reservadas.
+// A unicidade é verificada pelo serviço, que precisa consultar o banco.
+func ValidateSlug(slug string) error {
+	if !slugPattern.MatchString(slug) || strings.Contains(slug, "--") {
+		return ErrInvalidSlug
+	}
+	if _, reserved := reservedSlugs[slug]; reserved {
+		return ErrSlugReserved
+	}
+	return nil
+}
+
+// PublicPath retorna a URL pública preferida do perfil
+func (p *Profile) PublicPath() string {
+	if p.Slug != "" {
+		return "/u/" + p.Slug
+	}
+	return "/app/profile/" + p.ID
+}
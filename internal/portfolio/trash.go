@@ -0,0 +1,80 @@
+package portfolio
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProfileRestoreWindow é o prazo para restaurar um portfólio excluído
+const ProfileRestoreWindow = 30 * 24 * time.Hour
+
+// RestoreDeadline é o último momento em que o perfil excluído pode ser restaurado
+func (p *Profile) RestoreDeadline() time.Time {
+	if p.DeletedAt == nil {
+		return time.Time{}
+	}
+	return p.DeletedAt.Add(ProfileRestoreWindow)
+}
+
+// ListDeletedProfiles retorna os portfólios do usuário que ainda podem ser restaurados
+func (s *PortfolioService) ListDeletedProfiles(ctx context.Context, userID string) ([]*Profile, error) {
+	return s.repo.ListDeleted(ctx, userID, time.Now().Add(-ProfileRestoreWindow))
+}
+
+// RestoreProfile tira o portfólio da lixeira e, se ele estava publicado, o
+// devolve à busca. Respeita o limite de portfólios por usuário.
+func (s *PortfolioService) RestoreProfile(ctx context.Context, userID string, profileID string) (*Profile, error) {
+	if uuid.Validate(profileID) != nil {
+		return nil, ErrProfileNotFound
+	}
+	existing, err := s.repo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= MaxProfilesPerUser {
+		return nil, ErrProfileLimitReached
+	}
+
+	if err := s.repo.Restore(ctx, userID, profileID, time.Now().Add(-ProfileRestoreWindow)); err != nil {
+		return nil, err
+	}
+	profile, err := s.repo.Find(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+	if profile.PublishedAt != nil {
+		go s.reindexPublished(profile.ID, userID)
+	}
+	return s.withComputed(profile, nil)
+}
+
+// PurgeDeletedProfiles apaga definitivamente os portfólios cujo prazo de restauração acabou
+func (s *PortfolioService) PurgeDeletedProfiles(ctx context.Context) (int64, error) {
+	return s.repo.Purge(ctx, time.Now().Add(-ProfileRestoreWindow))
+}
+
+// StartPurgeJob executa PurgeDeletedProfiles ao iniciar e depois a cada interval,
+// até ctx ser cancelado
+func (s *PortfolioService) StartPurgeJob(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			purged, err := s.PurgeDeletedProfiles(ctx)
+			if err != nil {
+				log.Printf("PurgeDeletedProfiles error: %v", err)
+			} else if purged > 0 {
+				log.Printf("PurgeDeletedProfiles: %d portfólios removidos definitivamente", purged)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
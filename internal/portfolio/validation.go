@@ -0,0 +1,447 @@
+package portfolio
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	maxHeadlineLength = 255
+	maxBioLength      = 5000
+	maxYearsOfExp     = 70
+	maxNameLength     = 100
+	maxProjectMedia   = 12
+	maxCaptionLength  = 300
+	maxTitleLength    = 255
+	maxSkillYears     = 50
+)
+
+// supportedCurrencies lista os códigos ISO 4217 aceitos em Currency
+var supportedCurrencies = map[string]struct{}{
+	"BRL": {}, "USD": {}, "EUR": {}, "GBP": {}, "CAD": {}, "AUD": {}, "CHF": {},
+	"JPY": {}, "ARS": {}, "CLP": {}, "COP": {}, "MXN": {}, "PEN": {}, "UYU": {},
+}
+
+// FieldError descreve um problema em um campo específico. Path é um JSON Pointer
+// (RFC 6901) relativo ao corpo enviado, ex: "/experiences/0/endDate".
+type FieldError struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// ValidationErrors agrupa todos os problemas encontrados em uma requisição.
+// errors.Is(err, ErrInvalidProfileData) é verdadeiro para este tipo.
+type ValidationErrors []FieldError
+
+func (v ValidationErrors) Error() string {
+	parts := make([]string, len(v))
+	for i, fe := range v {
+		parts[i] = fe.Path + ": " + fe.Message
+	}
+	return ErrInvalidProfileData.Error() + ": " + strings.Join(parts, "; ")
+}
+
+func (v ValidationErrors) Unwrap() error {
+	return ErrInvalidProfileData
+}
+
+// validator acumula erros em vez de parar no primeiro
+type validator struct {
+	errs ValidationErrors
+}
+
+func (v *validator) add(path string, message string) {
+	v.errs = append(v.errs, FieldError{Path: path, Message: message})
+}
+
+func (v *validator) check(ok bool, path string, message string) {
+	if !ok {
+		v.add(path, message)
+	}
+}
+
+func (v *validator) err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs
+}
+
+// fieldNames mapeia os nomes dos campos de topo para o formato usado em cada
+// payload (SaveProfileInput usa snake_case, PatchProfileDTO usa camelCase)
+type fieldNames struct {
+	yearsOfExp        string
+	salaryExpectation string
+	contractType      string
+	socialLinks       string
+	fieldPrivacy      string
+	defaultLocale     string
+}
+
+var saveFieldNames = fieldNames{
+	yearsOfExp:        "years_of_experience",
+	salaryExpectation: "salary_expectation",
+	contractType:      "contract_type",
+	socialLinks:       "social_links",
+	fieldPrivacy:      "field_privacy",
+	defaultLocale:     "default_locale",
+}
+
+var patchFieldNames = fieldNames{
+	yearsOfExp:        "yearsOfExperience",
+	salaryExpectation: "salaryExpectation",
+	contractType:      "contractType",
+	socialLinks:       "socialLinks",
+	fieldPrivacy:      "fieldPrivacy",
+	defaultLocale:     "defaultLocale",
+}
+
+// Validate verifica o payload completo usado na criação/atualização do perfil
+func (in SaveProfileInput) Validate() error {
+	v := &validator{}
+	names := saveFieldNames
+
+	v.name("/name", in.Name)
+	v.headline("/headline", in.Headline)
+	v.bio("/bio", in.Bio)
+	v.seniority("/seniority", in.Seniority)
+	v.yearsOfExp("/"+names.yearsOfExp, in.YearsOfExp)
+	v.salary("/"+names.salaryExpectation, in.SalaryExpectation)
+	v.currency("/currency", in.Currency)
+	v.contractType("/"+names.contractType, in.ContractType)
+	v.location("/location", in.Location)
+	v.visibility("/visibility", in.Visibility)
+	if in.FieldPrivacy != nil {
+		v.fieldPrivacy("/"+names.fieldPrivacy, *in.FieldPrivacy)
+	}
+	v.slug("/slug", in.Slug)
+	v.skills("/skills", in.Skills)
+	v.socialLinks("/"+names.socialLinks, in.SocialLinks)
+	v.experiences("/experiences", in.Experiences)
+	v.projects("/projects", in.Projects)
+	v.educations("/educations", in.Educations)
+	v.certifications("/certifications", in.Certifications)
+	v.languages("/languages", in.Languages)
+	v.publications("/publications", in.Publications)
+	v.locale("/"+names.defaultLocale, in.DefaultLocale)
+	v.translations("/translations", in.Translations)
+
+	return v.err()
+}
+
+// Validate verifica apenas os campos presentes no PATCH
+func (dto PatchProfileDTO) Validate() error {
+	v := &validator{}
+	names := patchFieldNames
+
+	if dto.Name != nil {
+		v.name("/name", *dto.Name)
+		v.check(strings.TrimSpace(*dto.Name) != "", "/name", "não pode ser vazio")
+	}
+	if dto.Headline != nil {
+		v.headline("/headline", *dto.Headline)
+	}
+	if dto.Bio != nil {
+		v.bio("/bio", *dto.Bio)
+	}
+	if dto.Seniority != nil {
+		v.seniority("/seniority", *dto.Seniority)
+	}
+	if dto.YearsOfExp != nil {
+		v.yearsOfExp("/"+names.yearsOfExp, *dto.YearsOfExp)
+	}
+	if dto.SalaryExpectation != nil {
+		v.salary("/"+names.salaryExpectation, *dto.SalaryExpectation)
+	}
+	if dto.Currency != nil {
+		v.currency("/currency", *dto.Currency)
+	}
+	if dto.ContractType != nil {
+		v.contractType("/"+names.contractType, *dto.ContractType)
+	}
+	if dto.Location != nil {
+		v.location("/location", *dto.Location)
+	}
+	if dto.Visibility != nil {
+		v.check(dto.Visibility.IsValid(), "/visibility", "visibilidade inválida")
+	}
+	if dto.FieldPrivacy != nil {
+		v.fieldPrivacy("/"+names.fieldPrivacy, *dto.FieldPrivacy)
+	}
+	if dto.Slug != nil {
+		v.slug("/slug", *dto.Slug)
+	}
+	if dto.Skills != nil {
+		v.skills("/skills", *dto.Skills)
+	}
+	if dto.SocialLinks != nil {
+		v.socialLinks("/"+names.socialLinks, *dto.SocialLinks)
+	}
+	if dto.Experiences != nil {
+		v.experiences("/experiences", *dto.Experiences)
+	}
+	if dto.Projects != nil {
+		v.projects("/projects", *dto.Projects)
+	}
+	if dto.Educations != nil {
+		v.educations("/educations", *dto.Educations)
+	}
+	if dto.Certifications != nil {
+		v.certifications("/certifications", *dto.Certifications)
+	}
+	if dto.Languages != nil {
+		v.languages("/languages", *dto.Languages)
+	}
+	if dto.Publications != nil {
+		v.publications("/publications", *dto.Publications)
+	}
+	if dto.DefaultLocale != nil {
+		v.check(dto.DefaultLocale.IsValid(), "/"+names.defaultLocale, "idioma não suportado")
+	}
+	if dto.Translations != nil {
+		v.translations("/translations", *dto.Translations)
+	}
+
+	return v.err()
+}
+
+// --- Regras por campo ---
+
+func (v *validator) name(path string, name string) {
+	v.check(len([]rune(name)) <= maxNameLength, path, fmt.Sprintf("deve ter no máximo %d caracteres", maxNameLength))
+}
+
+func (v *validator) headline(path string, headline string) {
+	v.check(len([]rune(headline)) <= maxHeadlineLength, path, fmt.Sprintf("deve ter no máximo %d caracteres", maxHeadlineLength))
+}
+
+func (v *validator) bio(path string, bio string) {
+	v.check(len([]rune(bio)) <= maxBioLength, path, fmt.Sprintf("deve ter no máximo %d caracteres", maxBioLength))
+}
+
+func (v *validator) seniority(path string, s Seniority) {
+	if s == "" {
+		return
+	}
+	v.check(s.Int() != 0, path, "senioridade inválida")
+}
+
+func (v *validator) yearsOfExp(path string, years int) {
+	v.check(years >= 0 && years <= maxYearsOfExp, path, fmt.Sprintf("deve estar entre 0 e %d", maxYearsOfExp))
+}
+
+func (v *validator) salary(path string, salary float64) {
+	v.check(salary >= 0, path, "não pode ser negativa")
+}
+
+func (v *validator) currency(path string, currency string) {
+	if currency == "" {
+		return
+	}
+	_, ok := supportedCurrencies[currency]
+	v.check(ok, path, "código de moeda inválido (use ISO 4217, ex: BRL)")
+}
+
+func (v *validator) contractType(path string, contractType string) {
+	v.check(len(contractType) <= 50, path, "deve ter no máximo 50 caracteres")
+}
+
+func (v *validator) location(path string, location LocationType) {
+	if location == "" {
+		return
+	}
+	v.check(location.Int() != 0, path, "modalidade inválida")
+}
+
+func (v *validator) visibility(path string, visibility Visibility) {
+	if visibility == "" {
+		return
+	}
+	v.check(visibility.IsValid(), path, "visibilidade inválida")
+}
+
+func (v *validator) fieldPrivacy(path string, fp FieldPrivacy) {
+	v.check(fp.Salary.IsValid(), path+"/salary", "audiência inválida")
+	v.check(fp.Contract.IsValid(), path+"/contract", "audiência inválida")
+}
+
+func (v *validator) slug(path string, slug string) {
+	slug = NormalizeSlug(slug)
+	if slug == "" {
+		return
+	}
+	switch ValidateSlug(slug) {
+	case ErrInvalidSlug:
+		v.add(path, "use de 3 a 40 letras minúsculas, números ou hífens")
+	case ErrSlugReserved:
+		v.add(path, "este endereço é reservado")
+	}
+}
+
+func (v *validator) skills(path string, skills Skills) {
+	seen := make(map[string]bool)
+	for i, skill := range skills {
+		item := fmt.Sprintf("%s/%d", path, i)
+		key := strings.ToLower(strings.TrimSpace(skill.Name))
+		v.check(key != "", item+"/name", "não pode ser vazia")
+		v.check(key == "" || !seen[key], item+"/name", "habilidade repetida")
+		seen[key] = true
+		// Nível é opcional para manter compatíveis as habilidades cadastradas sem ele
+		v.check(skill.Level == "" || skill.Level.IsValid(), item+"/level", "nível inválido (use BEGINNER, INTERMEDIATE, ADVANCED ou EXPERT)")
+		v.check(skill.Years >= 0 && skill.Years <= maxSkillYears, item+"/years", fmt.Sprintf("deve estar entre 0 e %d", maxSkillYears))
+		if skill.LastUsed != nil {
+			v.check(!skill.LastUsed.After(time.Now()), item+"/lastUsed", "não pode estar no futuro")
+		}
+	}
+}
+
+func (v *validator) socialLinks(path string, links SocialLinks) {
+	v.url(path+"/linkedin", links.LinkedIn)
+	v.url(path+"/github", links.GitHub)
+	v.url(path+"/website", links.Website)
+}
+
+func (v *validator) experiences(path string, experiences Experiences) {
+	for i, exp := range experiences {
+		item := fmt.Sprintf("%s/%d", path, i)
+		v.check(strings.TrimSpace(exp.Company) != "", item+"/company", "obrigatório")
+		v.check(strings.TrimSpace(exp.Role) != "", item+"/role", "obrigatório")
+		v.dateRange(item, exp.StartDate, exp.EndDate)
+	}
+}
+
+func (v *validator) projects(path string, projects Projects) {
+	for i, proj := range projects {
+		item := fmt.Sprintf("%s/%d", path, i)
+		v.check(strings.TrimSpace(proj.Name) != "", item+"/name", "obrigatório")
+		v.url(item+"/repoUrl", proj.RepoURL)
+		v.url(item+"/liveUrl", proj.LiveURL)
+		v.projectMedia(item+"/media", proj.Media)
+	}
+}
+
+func (v *validator) projectMedia(path string, media []ProjectMedia) {
+	v.check(len(media) <= maxProjectMedia, path, fmt.Sprintf("máximo de %d itens por projeto", maxProjectMedia))
+	for i, m := range media {
+		item := fmt.Sprintf("%s/%d", path, i)
+		v.check(len([]rune(m.Caption)) <= maxCaptionLength, item+"/caption", fmt.Sprintf("deve ter no máximo %d caracteres", maxCaptionLength))
+		v.check(len([]rune(m.Alt)) <= maxCaptionLength, item+"/alt", fmt.Sprintf("deve ter no máximo %d caracteres", maxCaptionLength))
+		switch m.Kind {
+		case MediaImage:
+			// Imagens só podem vir do upload (nada de hotlink para outros sites)
+			v.check(strings.HasPrefix(m.URL, ProjectMediaURLPrefix), item+"/url", "envie a imagem pelo editor")
+			v.check(m.ThumbURL == "" || strings.HasPrefix(m.ThumbURL, ProjectMediaURLPrefix), item+"/thumbUrl", "envie a imagem pelo editor")
+		case MediaVideo:
+			v.check(m.URL != "", item+"/url", "obrigatório")
+			v.url(item+"/url", m.URL)
+		default:
+			v.add(item+"/kind", "tipo de mídia inválido")
+		}
+	}
+}
+
+func (v *validator) educations(path string, educations Educations) {
+	for i, edu := range educations {
+		item := fmt.Sprintf("%s/%d", path, i)
+		v.check(strings.TrimSpace(edu.Institution) != "", item+"/institution", "obrigatório")
+		v.dateRange(item, edu.StartDate, edu.EndDate)
+	}
+}
+
+func (v *validator) certifications(path string, certifications Certifications) {
+	for i, cert := range certifications {
+		item := fmt.Sprintf("%s/%d", path, i)
+		v.check(strings.TrimSpace(cert.Name) != "", item+"/name", "obrigatório")
+		v.check(strings.TrimSpace(cert.Issuer) != "", item+"/issuer", "obrigatório")
+		v.title(item+"/name", cert.Name)
+		v.title(item+"/issuer", cert.Issuer)
+		v.title(item+"/credentialId", cert.CredentialID)
+		v.url(item+"/verificationUrl", cert.VerificationURL)
+		if cert.IssueDate.IsZero() {
+			v.add(item+"/issueDate", "obrigatório")
+			continue
+		}
+		v.check(!cert.IssueDate.After(time.Now()), item+"/issueDate", "não pode estar no futuro")
+		if cert.ExpiryDate != nil {
+			v.check(cert.ExpiryDate.After(cert.IssueDate), item+"/expiryDate", "deve ser posterior à data de emissão")
+		}
+	}
+}
+
+func (v *validator) languages(path string, languages Languages) {
+	seen := make(map[string]bool)
+	for i, lang := range languages {
+		item := fmt.Sprintf("%s/%d", path, i)
+		v.check(IsKnownLanguage(lang.Language), item+"/language", "idioma desconhecido (use o código ISO 639-1, ex: en)")
+		v.check(lang.Level.IsValid(), item+"/level", "nível inválido (use A1 a C2 ou NATIVE)")
+		v.check(!seen[lang.Language], item+"/language", "idioma repetido")
+		seen[lang.Language] = true
+	}
+}
+
+func (v *validator) publications(path string, publications Publications) {
+	for i, pub := range publications {
+		item := fmt.Sprintf("%s/%d", path, i)
+		v.check(pub.Kind.IsValid(), item+"/kind", "tipo de publicação inválido")
+		v.check(strings.TrimSpace(pub.Title) != "", item+"/title", "obrigatório")
+		v.title(item+"/title", pub.Title)
+		v.title(item+"/publisher", pub.Publisher)
+		v.url(item+"/url", pub.URL)
+		v.check(len([]rune(pub.Description)) <= maxBioLength, item+"/description", fmt.Sprintf("deve ter no máximo %d caracteres", maxBioLength))
+		if pub.Date.IsZero() {
+			v.add(item+"/date", "obrigatório")
+		}
+	}
+}
+
+func (v *validator) title(path string, title string) {
+	v.check(len([]rune(title)) <= maxTitleLength, path, fmt.Sprintf("deve ter no máximo %d caracteres", maxTitleLength))
+}
+
+func (v *validator) locale(path string, locale Locale) {
+	if locale == "" {
+		return
+	}
+	v.check(locale.IsValid(), path, "idioma não suportado")
+}
+
+func (v *validator) translations(path string, translations Translations) {
+	for locale, t := range translations {
+		item := path + "/" + string(locale)
+		if !locale.IsValid() {
+			v.add(item, "idioma não suportado")
+			continue
+		}
+		v.headline(item+"/headline", t.Headline)
+		v.bio(item+"/bio", t.Bio)
+		for i, desc := range t.Experiences {
+			v.check(len([]rune(desc)) <= maxBioLength, fmt.Sprintf("%s/experiences/%d", item, i), fmt.Sprintf("deve ter no máximo %d caracteres", maxBioLength))
+		}
+		for i, desc := range t.Projects {
+			v.check(len([]rune(desc)) <= maxBioLength, fmt.Sprintf("%s/projects/%d", item, i), fmt.Sprintf("deve ter no máximo %d caracteres", maxBioLength))
+		}
+	}
+}
+
+// dateRange valida startDate/endDate de um item (endDate nil = atual)
+func (v *validator) dateRange(item string, start time.Time, end *time.Time) {
+	if start.IsZero() {
+		v.add(item+"/startDate", "obrigatório")
+		return
+	}
+	v.check(!start.After(time.Now()), item+"/startDate", "não pode estar no futuro")
+	if end != nil {
+		v.check(!end.Before(start), item+"/endDate", "não pode ser anterior à data de início")
+	}
+}
+
+// url aceita vazio ou uma URL absoluta http(s)
+func (v *validator) url(path string, raw string) {
+	if raw == "" {
+		return
+	}
+	u, err := url.Parse(raw)
+	v.check(err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "", path, "URL inválida (use http:// ou https://)")
+}
@@ -0,0 +1,40 @@
+package portfolio
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ETag identifica a versão do rascunho, usada com If-Match para evitar que um
+// salvamento sobrescreva alterações feitas em outra aba ou cliente
+func (p *Profile) ETag() string {
+	return `"v` + strconv.Itoa(p.Version) + `"`
+}
+
+// ParseIfMatch converte o cabeçalho If-Match na versão esperada do perfil.
+// Cabeçalho ausente ou "*" retornam 0 (sem verificação); ok é false quando a
+// tag não foi gerada por ETag, o que deve ser tratado como conflito.
+func ParseIfMatch(header string) (version int, ok bool) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return 0, true
+	}
+	// Pré-condições de escrita usam comparação forte: tags fracas nunca casam
+	if !strings.HasPrefix(header, `"v`) || !strings.HasSuffix(header, `"`) {
+		return 0, false
+	}
+	version, err := strconv.Atoi(header[2 : len(header)-1])
+	if err != nil || version <= 0 {
+		return 0, false
+	}
+	return version, true
+}
+
+// checkVersion recusa a atualização quando o cliente editou uma versão antiga.
+// expectedVersion 0 significa que o cliente não enviou pré-condição.
+func (p *Profile) checkVersion(expectedVersion int) error {
+	if expectedVersion != 0 && expectedVersion != p.Version {
+		return ErrVersionConflict
+	}
+	return nil
+}
@@ -15,8 +15,24 @@ type ProfileSearchQueryBuilder struct {
 	contractType []string
 	minYearsExp  *int
 	maxYearsExp  *int
-	minSalary    *float64
-	maxSalary    *float64
+	// useComputedYearsExp filtra pela experiência calculada a partir das experiências
+	// em vez do valor informado manualmente
+	useComputedYearsExp bool
+	minSalary           *float64
+	maxSalary           *float64
+	// minCompleteness filtra perfis com pontuação de preenchimento mínima (0 a 100)
+	minCompleteness *int
+	// locale restringe a busca a perfis disponíveis no idioma (ex: "en")
+	locale string
+	// skillLevels e skillYears exigem habilidades com nível ou tempo mínimos,
+	// no formato indexado "go:ADVANCED" e "kubernetes:3"
+	skillLevels []string
+	skillYears  []string
+	// expandSkill retorna as grafias equivalentes de uma habilidade (nome
+	// canônico e apelidos); nil compara apenas o texto informado
+	expandSkill func(skill string) []string
+	// languages exige idiomas falados, no formato "en" ou "en:C1" (nível mínimo)
+	languages []string
 }
 
 // NewProfileSearchQueryBuilder creates a new query builder
@@ -36,6 +52,27 @@ func (b *ProfileSearchQueryBuilder) WithSkills(skills ...string) *ProfileSearchQ
 	return b
 }
 
+// WithSkillLevels requires skills at a minimum level (AND condition). Each
+// value is a lowercased skill name and a level, e.g. "go:ADVANCED"
+func (b *ProfileSearchQueryBuilder) WithSkillLevels(terms ...string) *ProfileSearchQueryBuilder {
+	b.skillLevels = terms
+	return b
+}
+
+// WithSkillYears requires skills used for a minimum number of years (AND
+// condition). Each value is a lowercased skill name and years, e.g. "kubernetes:3"
+func (b *ProfileSearchQueryBuilder) WithSkillYears(terms ...string) *ProfileSearchQueryBuilder {
+	b.skillYears = terms
+	return b
+}
+
+// WithSkillAliases expands each skill filter to all its known spellings (OR
+// condition), e.g. "Go" also matches profiles indexed with "golang"
+func (b *ProfileSearchQueryBuilder) WithSkillAliases(expand func(skill string) []string) *ProfileSearchQueryBuilder {
+	b.expandSkill = expand
+	return b
+}
+
 // WithSeniority filters by seniority levels (OR condition)
 func (b *ProfileSearchQueryBuilder) WithSeniority(seniority ...int) *ProfileSearchQueryBuilder {
 	b.seniority = seniority
@@ -79,6 +116,13 @@ func (b *ProfileSearchQueryBuilder) WithMaxYearsOfExperience(max *int) *ProfileS
 	return b
 }
 
+// WithComputedYearsOfExperience makes the years range use the experience computed
+// from the profile's experience history instead of the self-declared value
+func (b *ProfileSearchQueryBuilder) WithComputedYearsOfExperience(useComputed bool) *ProfileSearchQueryBuilder {
+	b.useComputedYearsExp = useComputed
+	return b
+}
+
 // WithSalaryRange sets the salary range filter
 func (b *ProfileSearchQueryBuilder) WithMinSalaryRange(min *float64) *ProfileSearchQueryBuilder {
 	b.minSalary = min
@@ -90,6 +134,31 @@ func (b *ProfileSearchQueryBuilder) WithMaxSalaryRange(max *float64) *ProfileSea
 	return b
 }
 
+// WithMinCompleteness filters out profiles below the given completeness score (0-100)
+func (b *ProfileSearchQueryBuilder) WithMinCompleteness(min *int) *ProfileSearchQueryBuilder {
+	b.minCompleteness = min
+	return b
+}
+
+// WithLocale restricts results to profiles available in the given locale and
+// tokenizes the query with that language's rules
+func (b *ProfileSearchQueryBuilder) WithLocale(locale string) *ProfileSearchQueryBuilder {
+	b.locale = locale
+	return b
+}
+
+// WithLanguages requires spoken languages (AND condition). Each value is an
+// ISO 639-1 code optionally followed by a minimum CEFR level, e.g. "en:C1"
+func (b *ProfileSearchQueryBuilder) WithLanguages(languages ...string) *ProfileSearchQueryBuilder {
+	b.languages = languages
+	return b
+}
+
+// Locale returns the locale requested for the search (empty for any)
+func (b *ProfileSearchQueryBuilder) Locale() string {
+	return b.locale
+}
+
 // BuildQuery returns the full-text search query string
 func (b *ProfileSearchQueryBuilder) BuildQuery() string {
 	return strings.Join(b.keyWords, " ")
@@ -99,15 +168,25 @@ func (b *ProfileSearchQueryBuilder) BuildQuery() string {
 func (b *ProfileSearchQueryBuilder) BuildFilter() string {
 	var filters []string
 
-	// Skills (OR between skills)
+	// Skills (OR between skills and their aliases)
 	if len(b.skills) > 0 {
-		skillFilters := make([]string, len(b.skills))
-		for i, skill := range b.skills {
-			skillFilters[i] = fmt.Sprintf("skills = '%s'", skill)
+		var skillFilters []string
+		for _, skill := range b.skills {
+			for _, spelling := range b.skillSpellings(skill) {
+				skillFilters = append(skillFilters, fmt.Sprintf("skills = %s", quoteFilterValue(spelling)))
+			}
 		}
 		filters = append(filters, "("+strings.Join(skillFilters, " OR ")+")")
 	}
 
+	// Skill levels and years (AND between values, OR between aliases)
+	for _, term := range b.skillLevels {
+		filters = append(filters, b.skillTermFilter("skillLevels", term))
+	}
+	for _, term := range b.skillYears {
+		filters = append(filters, b.skillTermFilter("skillYears", term))
+	}
+
 	// Seniority (OR between values)
 	if len(b.seniority) > 0 {
 		senFilters := make([]string, len(b.seniority))
@@ -146,12 +225,16 @@ func (b *ProfileSearchQueryBuilder) BuildFilter() string {
 	}
 
 	// Years of Experience (range)
+	yearsAttr := "yearsOfExperience"
+	if b.useComputedYearsExp {
+		yearsAttr = "computedYearsOfExperience"
+	}
 	if b.minYearsExp != nil && b.maxYearsExp != nil {
-		filters = append(filters, fmt.Sprintf("yearsOfExperience %d TO %d", *b.minYearsExp, *b.maxYearsExp))
+		filters = append(filters, fmt.Sprintf("%s %d TO %d", yearsAttr, *b.minYearsExp, *b.maxYearsExp))
 	} else if b.minYearsExp != nil {
-		filters = append(filters, fmt.Sprintf("yearsOfExperience >= %d", *b.minYearsExp))
+		filters = append(filters, fmt.Sprintf("%s >= %d", yearsAttr, *b.minYearsExp))
 	} else if b.maxYearsExp != nil {
-		filters = append(filters, fmt.Sprintf("yearsOfExperience <= %d", *b.maxYearsExp))
+		filters = append(filters, fmt.Sprintf("%s <= %d", yearsAttr, *b.maxYearsExp))
 	}
 
 	// Salary Range
@@ -163,5 +246,60 @@ func (b *ProfileSearchQueryBuilder) BuildFilter() string {
 		filters = append(filters, fmt.Sprintf("salaryExpectation <= %.2f", *b.maxSalary))
 	}
 
+	// Completeness
+	if b.minCompleteness != nil {
+		filters = append(filters, fmt.Sprintf("completenessScore >= %d", *b.minCompleteness))
+	}
+
+	// Spoken languages (AND between values)
+	for _, lang := range b.languages {
+		filters = append(filters, fmt.Sprintf("languages = %s", quoteFilterValue(lang)))
+	}
+
+	// Locale
+	if b.locale != "" {
+		filters = append(filters, fmt.Sprintf("locales = %s", quoteFilterValue(b.locale)))
+	}
+
 	return strings.Join(filters, " AND ")
 }
+
+// skillSpellings retorna as grafias da habilidade sem repetições (a
+// comparação do Meilisearch não diferencia maiúsculas)
+func (b *ProfileSearchQueryBuilder) skillSpellings(skill string) []string {
+	if b.expandSkill == nil {
+		return []string{skill}
+	}
+	seen := make(map[string]bool)
+	var spellings []string
+	for _, spelling := range b.expandSkill(skill) {
+		if lower := strings.ToLower(spelling); !seen[lower] {
+			seen[lower] = true
+			spellings = append(spellings, spelling)
+		}
+	}
+	return spellings
+}
+
+// skillTermFilter expande o nome de um termo "nome:sufixo" (ex: "go:ADVANCED")
+// para todas as grafias da habilidade
+func (b *ProfileSearchQueryBuilder) skillTermFilter(attribute string, term string) string {
+	i := strings.LastIndex(term, ":")
+	if i < 0 {
+		return fmt.Sprintf("%s = %s", attribute, quoteFilterValue(term))
+	}
+	name, suffix := term[:i], term[i:]
+	var termFilters []string
+	for _, spelling := range b.skillSpellings(name) {
+		termFilters = append(termFilters, fmt.Sprintf("%s = %s", attribute, quoteFilterValue(strings.ToLower(spelling)+suffix)))
+	}
+	return "(" + strings.Join(termFilters, " OR ") + ")"
+}
+
+// quoteFilterValue delimita um valor de texto livre para o filtro do Meilisearch,
+// escapando aspas e barras para que o valor não altere a expressão
+func quoteFilterValue(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, "'", `\'`)
+	return "'" + value + "'"
+}
@@ -1,6 +1,7 @@
 package search
 
 import (
+	"encoding/json"
 	"log"
 	"portfolio/internal/config"
 
@@ -16,12 +17,47 @@ type ProfileSearchDTO struct {
 	Skills            []string `json:"skills"`
 	Seniority         int      `json:"seniority"`
 	YearsOfExp        int      `json:"yearsOfExperience"`
+	ComputedYearsExp  int      `json:"computedYearsOfExperience"`
 	Location          int      `json:"location"`
 	OpenToWork        bool     `json:"openToWork"`
 	ContractType      string   `json:"contractType"`
 	Currency          string   `json:"currency"`
 	SalaryExpectation float64  `json:"salaryExpectation"`
 	RemoteOnly        bool     `json:"remoteOnly"`
+	CompletenessScore int      `json:"completenessScore"`
+	// Locales lista os idiomas em que o perfil está disponível (ex: "pt-BR", "en")
+	Locales []string `json:"locales"`
+	// I18n guarda os textos de cada idioma; cada um é tokenizado com as regras
+	// da língua correspondente (ver localizedAttributes em ConfigureIndex)
+	I18n map[string]LocalizedText `json:"i18n"`
+	// SkillLevels e SkillYears são as habilidades com todos os níveis e anos até
+	// o declarado (ex: "go:BEGINNER", ..., "go:ADVANCED" e "go:1", ..., "go:5"),
+	// para filtrar por nível e tempo mínimos
+	SkillLevels []string `json:"skillLevels"`
+	SkillYears  []string `json:"skillYears"`
+	// Languages são os idiomas falados com todos os níveis até o declarado
+	// (ex: "en", "en:A1", ..., "en:C1"), para filtrar por nível mínimo
+	Languages []string `json:"languages"`
+	// Certifications são os nomes e emissores das certificações válidas
+	Certifications []string `json:"certifications"`
+	// Publications são os títulos de artigos e palestras
+	Publications []string `json:"publications"`
+	// EndorsementCount é o total de endossos recebidos nas habilidades do perfil
+	EndorsementCount int `json:"endorsementCount"`
+}
+
+// LocalizedText são os textos pesquisáveis de um perfil em um idioma
+type LocalizedText struct {
+	Headline     string   `json:"headline"`
+	Bio          string   `json:"bio"`
+	Descriptions []string `json:"descriptions"`
+}
+
+// SearchLocales mapeia os idiomas do perfil para os códigos ISO 639-3 usados pelo Meilisearch
+var SearchLocales = map[string]string{
+	"pt-BR": "por",
+	"en":    "eng",
+	"es":    "spa",
 }
 
 type ProfileSearchResponseDTO struct {
@@ -32,6 +68,8 @@ type ProfileSearchResponseDTO struct {
 	Seniority        int      `json:"seniority"`
 	Skills           []string `json:"skills"`
 	Location         int      `json:"location"`
+	// I18n só é retornado quando a busca pede um idioma
+	I18n map[string]LocalizedText `json:"i18n,omitempty"`
 }
 
 type ProfileSearchResponse struct {
@@ -49,8 +87,13 @@ type SearchService interface {
 	IndexProfile(profile ProfileSearchDTO) error
 	DeleteProfile(id string) error
 	SearchProfiles(query *ProfileSearchQueryBuilder) (ProfileSearchResponse, error)
+	SkillFrequencies() (map[string]int64, error)
 }
 
+// maxSkillFacetValues limita quantas habilidades distintas o Meili conta em
+// SkillFrequencies (as mais frequentes, ver faceting em ConfigureIndex)
+const maxSkillFacetValues = 1000
+
 type meiliService struct {
 	client    meilisearch.ServiceManager
 	indexName string
@@ -82,13 +125,20 @@ func (s *meiliService) ConfigureIndex() error {
 	// Isso permite queries como: "skills = 'Go' AND remote_only = true"
 	filterableAttributes := []interface{}{
 		"skills",
+		"skillLevels",
+		"skillYears",
 		"seniority",
 		"yearsOfExperience",
+		"computedYearsOfExperience",
 		"salaryExpectation",
 		"location",
 		"remoteOnly",
 		"openToWork",
 		"contractType",
+		"completenessScore",
+		"locales",
+		"languages",
+		"certifications",
 	}
 	_, err = index.UpdateFilterableAttributes(&filterableAttributes)
 	if err != nil {
@@ -98,7 +148,9 @@ func (s *meiliService) ConfigureIndex() error {
 
 	sortableAttributes := []string{
 		"yearsOfExperience",
+		"computedYearsOfExperience",
 		"salaryExpectation",
+		"completenessScore",
 	}
 	_, err = index.UpdateSortableAttributes(&sortableAttributes)
 	if err != nil {
@@ -106,6 +158,50 @@ func (s *meiliService) ConfigureIndex() error {
 		return err
 	}
 
+	// As habilidades mais usadas alimentam as sugestões do autocomplete
+	_, err = index.UpdateFaceting(&meilisearch.Faceting{
+		MaxValuesPerFacet: maxSkillFacetValues,
+		SortFacetValuesBy: map[string]meilisearch.SortFacetType{
+			"*":      meilisearch.SortFacetTypeAlpha,
+			"skills": meilisearch.SortFacetTypeCount,
+		},
+	})
+	if err != nil {
+		log.Printf("Erro ao configurar facetas do Meili: %v", err)
+		return err
+	}
+
+	// Regras padrão do Meili + perfis mais endossados e mais completos como desempate
+	rankingRules := []string{
+		"words",
+		"typo",
+		"proximity",
+		"attribute",
+		"sort",
+		"exactness",
+		"endorsementCount:desc",
+		"completenessScore:desc",
+	}
+	_, err = index.UpdateRankingRules(&rankingRules)
+	if err != nil {
+		log.Printf("Erro ao configurar ranking do Meili: %v", err)
+		return err
+	}
+
+	// Cada idioma em i18n.<locale> usa a tokenização da sua língua
+	localizedAttributes := make([]*meilisearch.LocalizedAttributes, 0, len(SearchLocales))
+	for locale, code := range SearchLocales {
+		localizedAttributes = append(localizedAttributes, &meilisearch.LocalizedAttributes{
+			Locales:           []string{code},
+			AttributePatterns: []string{"i18n." + locale + ".*"},
+		})
+	}
+	_, err = index.UpdateLocalizedAttributes(localizedAttributes)
+	if err != nil {
+		log.Printf("Erro ao configurar idiomas do Meili: %v", err)
+		return err
+	}
+
 	log.Println("Meilisearch configurado com sucesso!")
 	return nil
 }
@@ -153,6 +249,15 @@ func (s *meiliService) SearchProfiles(query *ProfileSearchQueryBuilder) (Profile
 		searchRequest.Filter = filter
 	}
 
+	locale := ""
+	if query != nil {
+		locale = query.Locale()
+	}
+	if code, ok := SearchLocales[locale]; ok {
+		searchRequest.Locales = []string{code}
+		searchRequest.AttributesToRetrieve = append(searchRequest.AttributesToRetrieve, "i18n."+locale)
+	}
+
 	searchRes, err := s.client.Index(s.indexName).Search(searchQuery, searchRequest)
 	if err != nil {
 		log.Printf("Erro ao buscar perfis: %v", err)
@@ -165,6 +270,14 @@ func (s *meiliService) SearchProfiles(query *ProfileSearchQueryBuilder) (Profile
 		return ProfileSearchResponse{}, err
 	}
 
+	// Exibe o título no idioma pedido
+	for i := range results {
+		if text, ok := results[i].I18n[locale]; ok && text.Headline != "" {
+			results[i].Headline = text.Headline
+		}
+		results[i].I18n = nil
+	}
+
 	hitCount := searchRes.EstimatedTotalHits
 	response := ProfileSearchResponse{
 		TotalHits: hitCount,
@@ -174,3 +287,29 @@ func (s *meiliService) SearchProfiles(query *ProfileSearchQueryBuilder) (Profile
 	log.Printf("Busca retornou %d perfis", len(results))
 	return response, nil
 }
+
+// SkillFrequencies retorna quantos perfis indexados declaram cada habilidade
+func (s *meiliService) SkillFrequencies() (map[string]int64, error) {
+	searchRes, err := s.client.Index(s.indexName).Search("", &meilisearch.SearchRequest{
+		AttributesToRetrieve: []string{"profileId"},
+		Limit:                1,
+		Facets:               []string{"skills"},
+	})
+	if err != nil {
+		log.Printf("Erro ao buscar frequência das habilidades: %v", err)
+		return nil, err
+	}
+
+	var distribution map[string]map[string]int64
+	if len(searchRes.FacetDistribution) > 0 {
+		if err := json.Unmarshal(searchRes.FacetDistribution, &distribution); err != nil {
+			log.Printf("Erro ao decodificar frequência das habilidades: %v", err)
+			return nil, err
+		}
+	}
+	frequencies := distribution["skills"]
+	if frequencies == nil {
+		frequencies = map[string]int64{}
+	}
+	return frequencies, nil
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -13,8 +14,11 @@ import (
 	"portfolio/internal/database"
 	"portfolio/internal/sync"
 	"portfolio/internal/jwt"
+	"portfolio/internal/media"
 	"portfolio/internal/portfolio"
 	"portfolio/internal/search"
+	"portfolio/internal/storage"
+	"portfolio/internal/taxonomy"
 	"portfolio/internal/web"
 
 	"github.com/gorilla/mux"
@@ -27,6 +31,8 @@ type Application struct {
 	porfolioModule *portfolio.PortfolioModule
 	webModule      *web.WebModule
 	githubSyncModule *sync.GithubSyncModule
+	mediaModule    *media.MediaModule
+	taxonomyModule *taxonomy.TaxonomyModule
 }
 
 func NewApplication() *Application {
@@ -55,17 +61,39 @@ func NewApplication() *Application {
 	authService := auth.NewAuthService(cfg, userRepository, &jwtService)
 	authModule := auth.NewAuthModule(authService, &jwtService)
 
+	// taxonomia de habilidades (semeada a partir do arquivo embutido na primeira execução)
+	taxonomyService := taxonomy.NewTaxonomyService(taxonomy.NewTaxonomyRepository(db.GetDB()))
+	if err := taxonomyService.Load(context.Background()); err != nil {
+		log.Printf("Aviso: Falha ao carregar a taxonomia de habilidades, usando o arquivo embutido: %v", err)
+	}
+	// outras instâncias também alteram a taxonomia; recarrega para não ficar com o índice antigo
+	taxonomyService.StartReloadJob(context.Background(), 5*time.Minute)
+	taxonomyModule := taxonomy.NewTaxonomyModule(taxonomyService, &jwtService, cfg)
+
 	//portfolio
 	portfolioRepository := portfolio.NewProfileRepository(db.GetDB())
-	portfolioService := portfolio.NewPortfolioService(portfolioRepository, searchService, userRepository)
+	endorsementRepository := portfolio.NewEndorsementRepository(db.GetDB())
+	profileViewRepository := portfolio.NewProfileViewRepository(db.GetDB())
+	portfolioService := portfolio.NewPortfolioService(cfg, portfolioRepository, searchService, userRepository, taxonomyService, endorsementRepository, profileViewRepository)
 	porfolioModule := portfolio.NewPortfolioModule(portfolioService, &jwtService)
+	// Remove de vez os portfólios que passaram do prazo de restauração
+	portfolioService.StartPurgeJob(context.Background(), 6*time.Hour)
 
 	// web
-	webModule := web.NewWebModule(authService, &jwtService, portfolioService, searchService)
+	webModule := web.NewWebModule(authService, &jwtService, portfolioService, searchService, taxonomyService, cfg)
 
 	// github sync
 	githubSyncModule := sync.NewGithubSyncModule(&jwtService, userRepository)
 
+	// arquivos enviados (avatares e galerias de projetos)
+	blobStore, err := storage.NewBlobStore(cfg)
+	if err != nil {
+		log.Fatalf("failed to configure blob storage: %v", err)
+	}
+	avatarService := media.NewAvatarService(blobStore, userRepository)
+	projectMediaService := media.NewProjectMediaService(blobStore)
+	mediaModule := media.NewMediaModule(avatarService, projectMediaService, &jwtService)
+
 
 	app := &Application{
 		config:         *cfg,
@@ -74,6 +102,8 @@ func NewApplication() *Application {
 		porfolioModule: porfolioModule,
 		webModule:      webModule,
 		githubSyncModule: githubSyncModule,
+		mediaModule:    mediaModule,
+		taxonomyModule: taxonomyModule,
 	}
 	return app
 }
@@ -87,6 +117,8 @@ func (s *Application) RegisterRoutes() http.Handler {
 	router.HandleFunc("/health", s.healthHandler)
 	router.PathPrefix("/auth").Handler(http.StripPrefix("/auth", s.authModule.RegisterAuthRoutes()))
 	router.PathPrefix("/portfolio").Handler(http.StripPrefix("/portfolio", s.porfolioModule.RegisterRoutes()))
+	router.PathPrefix("/media").Handler(http.StripPrefix("/media", s.mediaModule.RegisterRoutes()))
+	router.PathPrefix("/admin").Handler(http.StripPrefix("/admin", s.taxonomyModule.RegisterRoutes()))
 	return s.corsMiddleware(router)
 }
 
@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"path"
+	"portfolio/internal/config"
+	"strings"
+	"time"
+)
+
+var ErrBlobNotFound = errors.New("blob not found")
+var ErrInvalidBlobKey = errors.New("invalid blob key")
+
+// BlobInfo descreve um arquivo armazenado
+type BlobInfo struct {
+	Key         string
+	ContentType string
+	Size        int64
+	ModTime     time.Time
+}
+
+// BlobStore guarda arquivos enviados pelos usuários (avatares, imagens de projetos).
+// As chaves usam "/" como separador, ex: "avatars/{user_id}/{versão}/256.jpg".
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get retorna o conteúdo do arquivo; o chamador deve fechar o ReadCloser.
+	// Retorna ErrBlobNotFound se a chave não existir.
+	Get(ctx context.Context, key string) (io.ReadCloser, BlobInfo, error)
+	// Delete remove o arquivo; chaves inexistentes não são erro
+	Delete(ctx context.Context, key string) error
+	// DeletePrefix remove todos os arquivos abaixo de prefix, tratado como
+	// diretório ("avatars/u1" não inclui "avatars/u10")
+	DeletePrefix(ctx context.Context, prefix string) error
+}
+
+// NewBlobStore cria o BlobStore configurado em STORAGE_DRIVER
+func NewBlobStore(cfg *config.Config) (BlobStore, error) {
+	switch cfg.StorageDriver {
+	case "local":
+		return NewLocalStore(cfg.StorageLocalPath)
+	case "s3":
+		return NewS3Store(S3Options{
+			Endpoint:        cfg.S3Endpoint,
+			Region:          cfg.S3Region,
+			Bucket:          cfg.S3Bucket,
+			AccessKeyID:     cfg.S3AccessKeyID,
+			SecretAccessKey: cfg.S3SecretAccessKey,
+			UseSSL:          cfg.S3UseSSL,
+		})
+	case "memory":
+		return NewMemoryStore(), nil
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", cfg.StorageDriver)
+	}
+}
+
+// validateKey rejeita chaves vazias, absolutas ou com "..", que poderiam
+// escapar do diretório/bucket configurado
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") || path.Clean(key) != key {
+		return ErrInvalidBlobKey
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == ".." || part == "." {
+			return ErrInvalidBlobKey
+		}
+	}
+	return nil
+}
+
+// contentTypeFor deduz o tipo pelo sufixo da chave (usado quando o backend não guarda o tipo)
+func contentTypeFor(key string) string {
+	if contentType := mime.TypeByExtension(path.Ext(key)); contentType != "" {
+		return contentType
+	}
+	return "application/octet-stream"
+}
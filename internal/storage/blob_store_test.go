@@ -0,0 +1,38 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateKey(t *testing.T) {
+	tests := []struct {
+		key   string
+		valid bool
+	}{
+		{"avatars/u1/v1/lg.jpg", true},
+		{"avatars/u1", true},
+		{"file.png", true},
+		{"", false},
+		{".", false},
+		{"..", false},
+		{"../etc/passwd", false},
+		{"avatars/../secrets", false},
+		{"avatars/./u1", false},
+		{"avatars/u1/..", false},
+		{"/avatars/u1", false},
+		{`avatars\u1`, false},
+		{`..\secrets`, false},
+		{"avatars//u1", false},
+		{"avatars/u1/", false},
+	}
+	for _, tt := range tests {
+		err := validateKey(tt.key)
+		if tt.valid && err != nil {
+			t.Errorf("validateKey(%q) = %v, want nil", tt.key, err)
+		}
+		if !tt.valid && !errors.Is(err, ErrInvalidBlobKey) {
+			t.Errorf("validateKey(%q) = %v, want ErrInvalidBlobKey", tt.key, err)
+		}
+	}
+}
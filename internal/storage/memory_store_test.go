@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestMemoryStoreDeletePrefix(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix string
+		want   []string
+	}{
+		{"remove apenas o diretório informado", "avatars/u1", []string{"avatars/u10/v1/lg.jpg", "avatars/u2/v1/lg.jpg", "projects/u1/m1/full.jpg"}},
+		{"versão específica", "avatars/u1/v1", []string{"avatars/u1/v2/lg.jpg", "avatars/u10/v1/lg.jpg", "avatars/u2/v1/lg.jpg", "projects/u1/m1/full.jpg"}},
+		{"prefixo sem arquivos", "avatars/u3", []string{"avatars/u1/v1/lg.jpg", "avatars/u1/v1/sm.jpg", "avatars/u1/v2/lg.jpg", "avatars/u10/v1/lg.jpg", "avatars/u2/v1/lg.jpg", "projects/u1/m1/full.jpg"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := NewMemoryStore()
+			for _, key := range []string{
+				"avatars/u1/v1/lg.jpg",
+				"avatars/u1/v1/sm.jpg",
+				"avatars/u1/v2/lg.jpg",
+				"avatars/u10/v1/lg.jpg",
+				"avatars/u2/v1/lg.jpg",
+				"projects/u1/m1/full.jpg",
+			} {
+				if err := store.Put(ctx, key, strings.NewReader("x"), 1, ""); err != nil {
+					t.Fatalf("Put(%q): %v", key, err)
+				}
+			}
+
+			if err := store.DeletePrefix(ctx, tt.prefix); err != nil {
+				t.Fatalf("DeletePrefix(%q): %v", tt.prefix, err)
+			}
+			keys := store.Keys()
+			sort.Strings(keys)
+			if strings.Join(keys, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("keys = %v, want %v", keys, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryStoreDeletePrefixInvalid(t *testing.T) {
+	store := NewMemoryStore()
+	for _, prefix := range []string{"", "avatars/", "../avatars", "/avatars"} {
+		if err := store.DeletePrefix(context.Background(), prefix); !errors.Is(err, ErrInvalidBlobKey) {
+			t.Errorf("DeletePrefix(%q) = %v, want ErrInvalidBlobKey", prefix, err)
+		}
+	}
+}
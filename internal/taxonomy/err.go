@@ -0,0 +1,7 @@
+package taxonomy
+
+import "errors"
+
+var ErrSkillNotFound = errors.New("skill not found")
+var ErrSkillConflict = errors.New("skill name or alias already belongs to another skill")
+var ErrInvalidSkill = errors.New("invalid skill data")
@@ -0,0 +1,137 @@
+package taxonomy
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"portfolio/internal/config"
+	"portfolio/internal/jwt"
+
+	"github.com/gorilla/mux"
+)
+
+type TaxonomyModule struct {
+	service    *TaxonomyService
+	jwtService *jwt.JWTService
+	cfg        *config.Config
+}
+
+func NewTaxonomyModule(service *TaxonomyService, jwtService *jwt.JWTService, cfg *config.Config) *TaxonomyModule {
+	return &TaxonomyModule{
+		service:    service,
+		jwtService: jwtService,
+		cfg:        cfg,
+	}
+}
+
+// RegisterRoutes registra a API administrativa (montada em /admin)
+func (module *TaxonomyModule) RegisterRoutes() *mux.Router {
+	router := mux.NewRouter()
+
+	router.HandleFunc("/skills", module.requireAdmin(module.listSkills)).Methods("GET")
+	router.HandleFunc("/skills", module.requireAdmin(module.createSkill)).Methods("POST")
+	router.HandleFunc("/skills/{skill_id}", module.requireAdmin(module.getSkill)).Methods("GET")
+	router.HandleFunc("/skills/{skill_id}", module.requireAdmin(module.updateSkill)).Methods("PUT")
+	router.HandleFunc("/skills/{skill_id}", module.requireAdmin(module.deleteSkill)).Methods("DELETE")
+
+	return router
+}
+
+// requireAdmin exige um usuário autenticado cujo e-mail esteja em ADMIN_EMAILS
+func (module *TaxonomyModule) requireAdmin(next http.HandlerFunc) http.HandlerFunc {
+	return module.jwtService.RequiredAutenticationMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		user := jwt.GetUserCurrentUser(r.Context())
+		if !module.cfg.IsAdmin(user.Email) {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+		next(w, r)
+	})
+}
+
+// listSkills aceita ?category= para filtrar (ex: LANGUAGE, CLOUD)
+func (module *TaxonomyModule) listSkills(w http.ResponseWriter, r *http.Request) {
+	category := Category(r.URL.Query().Get("category"))
+	if category != "" && !category.IsValid() {
+		http.Error(w, "Unknown category", http.StatusBadRequest)
+		return
+	}
+	skills, err := module.service.List(r.Context(), category)
+	if err != nil {
+		log.Printf("ListSkills error: %v", err)
+		http.Error(w, "Failed to list skills", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(skills)
+}
+
+func (module *TaxonomyModule) getSkill(w http.ResponseWriter, r *http.Request) {
+	skill, err := module.service.Get(r.Context(), mux.Vars(r)["skill_id"])
+	if err != nil {
+		writeSkillError(w, "GetSkill", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(skill)
+}
+
+func (module *TaxonomyModule) createSkill(w http.ResponseWriter, r *http.Request) {
+	var input SkillInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	skill, err := module.service.Create(r.Context(), input)
+	if err != nil {
+		writeSkillError(w, "CreateSkill", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(skill)
+}
+
+func (module *TaxonomyModule) updateSkill(w http.ResponseWriter, r *http.Request) {
+	var input SkillInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	skill, err := module.service.Update(r.Context(), mux.Vars(r)["skill_id"], input)
+	if err != nil {
+		writeSkillError(w, "UpdateSkill", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(skill)
+}
+
+func (module *TaxonomyModule) deleteSkill(w http.ResponseWriter, r *http.Request) {
+	if err := module.service.Delete(r.Context(), mux.Vars(r)["skill_id"]); err != nil {
+		writeSkillError(w, "DeleteSkill", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeSkillError(w http.ResponseWriter, operation string, err error) {
+	switch {
+	case errors.Is(err, ErrSkillNotFound):
+		http.Error(w, "Skill not found", http.StatusNotFound)
+	case errors.Is(err, ErrSkillConflict):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, ErrInvalidSkill):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		log.Printf("%s error: %v", operation, err)
+		http.Error(w, "Failed to process skill", http.StatusInternalServerError)
+	}
+}
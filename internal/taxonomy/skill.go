@@ -0,0 +1,126 @@
+package taxonomy
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const maxSkillNameLength = 100
+
+// Category agrupa as habilidades da taxonomia
+type Category string
+
+const (
+	CategoryLanguage  Category = "LANGUAGE"
+	CategoryFramework Category = "FRAMEWORK"
+	CategoryDatabase  Category = "DATABASE"
+	CategoryCloud     Category = "CLOUD"
+	CategoryTool      Category = "TOOL"
+	CategoryPractice  Category = "PRACTICE"
+	CategoryOther     Category = "OTHER"
+)
+
+var Categories = []Category{CategoryLanguage, CategoryFramework, CategoryDatabase, CategoryCloud, CategoryTool, CategoryPractice, CategoryOther}
+
+func (c Category) IsValid() bool {
+	for _, category := range Categories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// Skill é uma entrada da taxonomia: o nome canônico gravado nos perfis e os
+// apelidos que são convertidos para ele (ex: "golang" -> "Go")
+type Skill struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Aliases   Aliases   `json:"aliases"`
+	Category  Category  `json:"category"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type Aliases []string
+
+func (a Aliases) Value() (driver.Value, error) {
+	return json.Marshal(a)
+}
+func (a *Aliases) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, &a)
+}
+
+// Keys retorna as chaves normalizadas do nome e de todos os apelidos
+func (s Skill) Keys() []string {
+	keys := []string{Key(s.Name)}
+	for _, alias := range s.Aliases {
+		keys = append(keys, Key(alias))
+	}
+	return keys
+}
+
+// SkillInput é o corpo aceito pela API administrativa
+type SkillInput struct {
+	Name     string   `json:"name"`
+	Aliases  Aliases  `json:"aliases"`
+	Category Category `json:"category"`
+}
+
+// normalize remove espaços extras e apelidos vazios ou repetidos (inclusive os
+// iguais ao próprio nome). Variações de grafia com a mesma Key são mantidas
+// porque a busca compara o texto gravado nos perfis já indexados.
+func (in SkillInput) normalize() SkillInput {
+	in.Name = strings.TrimSpace(in.Name)
+	seen := map[string]bool{strings.ToLower(in.Name): true}
+	aliases := make(Aliases, 0, len(in.Aliases))
+	for _, alias := range in.Aliases {
+		alias = strings.TrimSpace(alias)
+		lower := strings.ToLower(alias)
+		if Key(alias) == "" || seen[lower] {
+			continue
+		}
+		seen[lower] = true
+		aliases = append(aliases, alias)
+	}
+	in.Aliases = aliases
+	return in
+}
+
+func (in SkillInput) validate() error {
+	switch {
+	case Key(in.Name) == "":
+		return fmt.Errorf("%w: name is required", ErrInvalidSkill)
+	case len([]rune(in.Name)) > maxSkillNameLength:
+		return fmt.Errorf("%w: name must have at most %d characters", ErrInvalidSkill, maxSkillNameLength)
+	case !in.Category.IsValid():
+		return fmt.Errorf("%w: unknown category %q", ErrInvalidSkill, in.Category)
+	}
+	for _, alias := range in.Aliases {
+		if len([]rune(alias)) > maxSkillNameLength {
+			return fmt.Errorf("%w: aliases must have at most %d characters", ErrInvalidSkill, maxSkillNameLength)
+		}
+	}
+	return nil
+}
+
+// Key normaliza um nome de habilidade para comparação: minúsculas e sem
+// espaços, hífens, pontos ou sublinhados ("Go lang", "GoLang" e "golang"
+// viram "golang"). Símbolos como "#" e "+" são mantidos (C# ≠ C++ ≠ C).
+func Key(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || r == '-' || r == '.' || r == '_' {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, name)
+}
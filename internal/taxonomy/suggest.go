@@ -0,0 +1,186 @@
+package taxonomy
+
+import (
+	"sort"
+	"strings"
+)
+
+// Suggestion é uma habilidade sugerida no autocomplete
+type Suggestion struct {
+	Name     string   `json:"name"`
+	Category Category `json:"category,omitempty"`
+	// Alias é o apelido digitado quando ele difere do nome sugerido (ex: "golang" -> "Go")
+	Alias string `json:"alias,omitempty"`
+	// Count é a quantidade de perfis indexados que declaram a habilidade
+	Count int64 `json:"count"`
+}
+
+// Qualidade da correspondência com o texto digitado; menor é melhor
+const (
+	matchExact = iota
+	matchNamePrefix
+	matchAliasPrefix
+	matchContains
+	matchTypo
+	noMatch
+)
+
+type suggestionCandidate struct {
+	Suggestion
+	match int
+}
+
+// Suggest ordena as habilidades que correspondem ao texto digitado: primeiro
+// pela qualidade da correspondência (nome exato, prefixo do nome, prefixo de um
+// apelido, trecho, erro de digitação) e depois pela quantidade de perfis que as
+// usam. frequencies vem do índice de busca e pode conter nomes fora da
+// taxonomia, que também são sugeridos. Sem texto, retorna as mais usadas.
+func (s *TaxonomyService) Suggest(query string, frequencies map[string]int64, limit int) []Suggestion {
+	query = Key(query)
+	candidates := map[string]*suggestionCandidate{}
+
+	s.mu.RLock()
+	for _, skill := range s.skills {
+		match, alias := matchSkill(query, skill)
+		candidates[Key(skill.Name)] = &suggestionCandidate{
+			Suggestion: Suggestion{Name: skill.Name, Category: skill.Category, Alias: alias},
+			match:      match,
+		}
+	}
+	s.mu.RUnlock()
+
+	// Nomes indexados antes da normalização somam na entrada canônica
+	for name, count := range frequencies {
+		canonical := s.Canonical(name)
+		key := Key(canonical)
+		if key == "" {
+			continue
+		}
+		candidate, ok := candidates[key]
+		if !ok {
+			match, _ := matchSkill(query, Skill{Name: canonical})
+			candidate = &suggestionCandidate{Suggestion: Suggestion{Name: canonical}, match: match}
+			candidates[key] = candidate
+		}
+		candidate.Count += count
+	}
+
+	ranked := make([]suggestionCandidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		if candidate.match == noMatch || (query == "" && candidate.Count == 0) {
+			continue
+		}
+		ranked = append(ranked, *candidate)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].match != ranked[j].match {
+			return ranked[i].match < ranked[j].match
+		}
+		if ranked[i].Count != ranked[j].Count {
+			return ranked[i].Count > ranked[j].Count
+		}
+		return strings.ToLower(ranked[i].Name) < strings.ToLower(ranked[j].Name)
+	})
+
+	if limit > 0 && len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	suggestions := make([]Suggestion, 0, len(ranked))
+	for _, candidate := range ranked {
+		suggestions = append(suggestions, candidate.Suggestion)
+	}
+	return suggestions
+}
+
+// matchSkill compara o texto digitado (já normalizado por Key) com o nome e os
+// apelidos. Retorna também o apelido que gerou a correspondência, se houver.
+func matchSkill(query string, skill Skill) (int, string) {
+	if query == "" {
+		return matchNamePrefix, ""
+	}
+
+	name := Key(skill.Name)
+	switch {
+	case name == query:
+		return matchExact, ""
+	case strings.HasPrefix(name, query):
+		return matchNamePrefix, ""
+	}
+
+	best, bestAlias := noMatch, ""
+	for _, alias := range skill.Aliases {
+		key := Key(alias)
+		match := noMatch
+		switch {
+		case key == query:
+			match = matchExact
+		case strings.HasPrefix(key, query):
+			match = matchAliasPrefix
+		}
+		if match < best {
+			best, bestAlias = match, alias
+		}
+	}
+	if best != noMatch {
+		return best, bestAlias
+	}
+
+	// Trechos com uma letra só ("c" em "docker") gerariam ruído
+	if len(query) >= 2 {
+		for _, key := range skill.Keys() {
+			if strings.Contains(key, query) {
+				return matchContains, ""
+			}
+		}
+	}
+	if isTypo(query, name) {
+		return matchTypo, ""
+	}
+	return noMatch, ""
+}
+
+// isTypo aceita uma letra errada, faltando, sobrando ou trocada de lugar em
+// relação ao início do nome ("pyhton" -> "python", "kubernets" -> "kubernetes").
+// Textos curtos demais não são considerados para evitar sugestões aleatórias.
+func isTypo(query, name string) bool {
+	q, n := []rune(query), []rune(name)
+	if len(q) < 4 {
+		return false
+	}
+	best := editDistance(q, n)
+	// Compara também com prefixos do nome, já que o usuário ainda está digitando
+	for _, size := range []int{len(q) - 1, len(q), len(q) + 1} {
+		if size > 0 && size < len(n) {
+			if d := editDistance(q, n[:size]); d < best {
+				best = d
+			}
+		}
+	}
+	return best <= 1
+}
+
+// editDistance é a distância de Damerau-Levenshtein restrita (transposições de
+// letras vizinhas contam como uma edição)
+func editDistance(a, b []rune) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
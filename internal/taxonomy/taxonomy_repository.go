@@ -0,0 +1,146 @@
+package taxonomy
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type TaxonomyRepository interface {
+	List(ctx context.Context) ([]Skill, error)
+	Find(ctx context.Context, id string) (*Skill, error)
+	Create(ctx context.Context, skill *Skill) error
+	Update(ctx context.Context, skill *Skill) error
+	Delete(ctx context.Context, id string) error
+	// Seed grava as habilidades apenas se a tabela estiver vazia e indica se gravou
+	Seed(ctx context.Context, skills []Skill) (bool, error)
+}
+
+type taxonomyRepo struct {
+	db *sql.DB
+}
+
+func NewTaxonomyRepository(db *sql.DB) TaxonomyRepository {
+	return &taxonomyRepo{db: db}
+}
+
+const skillColumns = `id, name, aliases, category, created_at, updated_at`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSkill(row rowScanner) (*Skill, error) {
+	var s Skill
+	err := row.Scan(&s.ID, &s.Name, &s.Aliases, &s.Category, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrSkillNotFound
+		}
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *taxonomyRepo) List(ctx context.Context) ([]Skill, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+skillColumns+` FROM skill_taxonomy ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	skills := []Skill{}
+	for rows.Next() {
+		s, err := scanSkill(rows)
+		if err != nil {
+			return nil, err
+		}
+		skills = append(skills, *s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return skills, nil
+}
+
+func (r *taxonomyRepo) Find(ctx context.Context, id string) (*Skill, error) {
+	return scanSkill(r.db.QueryRowContext(ctx, `SELECT `+skillColumns+` FROM skill_taxonomy WHERE id = $1`, id))
+}
+
+func (r *taxonomyRepo) Create(ctx context.Context, s *Skill) error {
+	query := `
+		INSERT INTO skill_taxonomy (id, name, name_key, category, aliases, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err := r.db.ExecContext(ctx, query, s.ID, s.Name, Key(s.Name), s.Category, s.Aliases, s.CreatedAt, s.UpdatedAt)
+	return mapConflict(err)
+}
+
+func (r *taxonomyRepo) Update(ctx context.Context, s *Skill) error {
+	query := `
+		UPDATE skill_taxonomy
+		SET name = $1, name_key = $2, category = $3, aliases = $4, updated_at = $5
+		WHERE id = $6
+	`
+	result, err := r.db.ExecContext(ctx, query, s.Name, Key(s.Name), s.Category, s.Aliases, s.UpdatedAt, s.ID)
+	if err != nil {
+		return mapConflict(err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrSkillNotFound
+	}
+	return nil
+}
+
+func (r *taxonomyRepo) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM skill_taxonomy WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrSkillNotFound
+	}
+	return nil
+}
+
+func (r *taxonomyRepo) Seed(ctx context.Context, skills []Skill) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	// Trava a tabela para que duas instâncias subindo juntas não semeiem em dobro
+	if _, err := tx.ExecContext(ctx, `LOCK TABLE skill_taxonomy IN EXCLUSIVE MODE`); err != nil {
+		return false, err
+	}
+	var empty bool
+	if err := tx.QueryRowContext(ctx, `SELECT NOT EXISTS(SELECT 1 FROM skill_taxonomy)`).Scan(&empty); err != nil {
+		return false, err
+	}
+	if !empty {
+		return false, nil
+	}
+
+	for _, s := range skills {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO skill_taxonomy (id, name, name_key, category, aliases, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+		`, s.ID, s.Name, Key(s.Name), s.Category, s.Aliases, s.CreatedAt, s.UpdatedAt)
+		if err != nil {
+			return false, err
+		}
+	}
+	return true, tx.Commit()
+}
+
+// mapConflict converte a violação do índice único de name_key em ErrSkillConflict
+func mapConflict(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return ErrSkillConflict
+	}
+	return err
+}
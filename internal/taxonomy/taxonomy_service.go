@@ -0,0 +1,257 @@
+package taxonomy
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// seedFile é a taxonomia inicial, gravada no banco na primeira inicialização
+//
+//go:embed skills_seed.json
+var seedFile []byte
+
+// TaxonomyService mantém em memória o índice de nomes e apelidos, usado na
+// normalização ao salvar perfis e na expansão de apelidos na busca. O índice é
+// recarregado do banco a cada alteração feita pela API administrativa nesta
+// instância e, periodicamente, por StartReloadJob, para que as demais
+// instâncias recebam as alterações sem precisar reiniciar.
+type TaxonomyService struct {
+	repo TaxonomyRepository
+
+	mu     sync.RWMutex
+	skills []Skill
+	// byKey indexa as entradas pelas chaves normalizadas do nome e dos apelidos
+	byKey map[string]int
+}
+
+func NewTaxonomyService(repo TaxonomyRepository) *TaxonomyService {
+	s := &TaxonomyService{repo: repo}
+	// Até o primeiro Load (ou se o banco estiver indisponível) usa o arquivo embutido
+	s.index(seedSkills())
+	return s
+}
+
+// Load grava a taxonomia inicial se a tabela estiver vazia e carrega o índice
+func (s *TaxonomyService) Load(ctx context.Context) error {
+	seeded, err := s.repo.Seed(ctx, seedSkills())
+	if err != nil {
+		return err
+	}
+	if seeded {
+		log.Printf("Taxonomia de habilidades criada a partir do arquivo embutido")
+	}
+	return s.reload(ctx)
+}
+
+// StartReloadJob recarrega o índice do banco a cada interval até ctx ser cancelado.
+// Se o banco falhar, mantém o índice atual e tenta de novo no próximo ciclo.
+func (s *TaxonomyService) StartReloadJob(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			if err := s.reload(ctx); err != nil {
+				log.Printf("ReloadTaxonomy error: %v", err)
+			}
+		}
+	}()
+}
+
+func (s *TaxonomyService) reload(ctx context.Context) error {
+	skills, err := s.repo.List(ctx)
+	if err != nil {
+		return err
+	}
+	s.index(skills)
+	return nil
+}
+
+func (s *TaxonomyService) index(skills []Skill) {
+	sort.Slice(skills, func(i, j int) bool { return strings.ToLower(skills[i].Name) < strings.ToLower(skills[j].Name) })
+	byKey := make(map[string]int, len(skills)*2)
+	for i, skill := range skills {
+		for _, key := range skill.Keys() {
+			if _, taken := byKey[key]; !taken {
+				byKey[key] = i
+			}
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.skills = skills
+	s.byKey = byKey
+}
+
+// Lookup encontra a entrada da taxonomia pelo nome canônico ou por um apelido
+func (s *TaxonomyService) Lookup(name string) (Skill, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	i, ok := s.byKey[Key(name)]
+	if !ok {
+		return Skill{}, false
+	}
+	return s.skills[i], true
+}
+
+// Canonical retorna o nome canônico da habilidade ou o próprio nome (sem
+// espaços extras) quando ela não está na taxonomia
+func (s *TaxonomyService) Canonical(name string) string {
+	if skill, ok := s.Lookup(name); ok {
+		return skill.Name
+	}
+	return strings.TrimSpace(name)
+}
+
+// Expand retorna o nome canônico e todos os apelidos da habilidade, para que
+// a busca encontre perfis indexados antes da normalização. Habilidades fora da
+// taxonomia retornam apenas o nome informado.
+func (s *TaxonomyService) Expand(name string) []string {
+	skill, ok := s.Lookup(name)
+	if !ok {
+		return []string{strings.TrimSpace(name)}
+	}
+	return append([]string{skill.Name}, skill.Aliases...)
+}
+
+// Skills retorna a taxonomia carregada em memória, ordenada pelo nome
+func (s *TaxonomyService) Skills() []Skill {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]Skill(nil), s.skills...)
+}
+
+// List retorna a taxonomia gravada no banco, opcionalmente filtrada pela categoria
+func (s *TaxonomyService) List(ctx context.Context, category Category) ([]Skill, error) {
+	skills, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if category == "" {
+		return skills, nil
+	}
+	filtered := []Skill{}
+	for _, skill := range skills {
+		if skill.Category == category {
+			filtered = append(filtered, skill)
+		}
+	}
+	return filtered, nil
+}
+
+func (s *TaxonomyService) Get(ctx context.Context, id string) (*Skill, error) {
+	if uuid.Validate(id) != nil {
+		return nil, ErrSkillNotFound
+	}
+	return s.repo.Find(ctx, id)
+}
+
+func (s *TaxonomyService) Create(ctx context.Context, input SkillInput) (*Skill, error) {
+	input = input.normalize()
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+	if err := s.checkConflicts(ctx, input, ""); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	skill := &Skill{
+		ID:        uuid.New().String(),
+		Name:      input.Name,
+		Aliases:   input.Aliases,
+		Category:  input.Category,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.repo.Create(ctx, skill); err != nil {
+		return nil, err
+	}
+	return skill, s.reload(ctx)
+}
+
+func (s *TaxonomyService) Update(ctx context.Context, id string, input SkillInput) (*Skill, error) {
+	input = input.normalize()
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+	skill, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkConflicts(ctx, input, id); err != nil {
+		return nil, err
+	}
+
+	skill.Name = input.Name
+	skill.Aliases = input.Aliases
+	skill.Category = input.Category
+	skill.UpdatedAt = time.Now()
+	if err := s.repo.Update(ctx, skill); err != nil {
+		return nil, err
+	}
+	return skill, s.reload(ctx)
+}
+
+func (s *TaxonomyService) Delete(ctx context.Context, id string) error {
+	if uuid.Validate(id) != nil {
+		return ErrSkillNotFound
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	return s.reload(ctx)
+}
+
+// checkConflicts impede que o nome ou um apelido resolva para mais de uma
+// entrada. Recarrega o índice antes para considerar alterações de outras instâncias.
+func (s *TaxonomyService) checkConflicts(ctx context.Context, input SkillInput, exceptID string) error {
+	if err := s.reload(ctx); err != nil {
+		return err
+	}
+	candidate := Skill{Name: input.Name, Aliases: input.Aliases}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, key := range candidate.Keys() {
+		if i, taken := s.byKey[key]; taken && s.skills[i].ID != exceptID {
+			return ErrSkillConflict
+		}
+	}
+	return nil
+}
+
+// seedSkills lê o arquivo embutido. Um arquivo inválido é erro de build, por isso panic.
+func seedSkills() []Skill {
+	var inputs []SkillInput
+	if err := json.Unmarshal(seedFile, &inputs); err != nil {
+		panic("taxonomy: invalid skills_seed.json: " + err.Error())
+	}
+	now := time.Now()
+	skills := make([]Skill, 0, len(inputs))
+	for _, input := range inputs {
+		input = input.normalize()
+		skills = append(skills, Skill{
+			ID:        uuid.New().String(),
+			Name:      input.Name,
+			Aliases:   input.Aliases,
+			Category:  input.Category,
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+	}
+	return skills
+}
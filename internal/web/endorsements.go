@@ -0,0 +1,253 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"portfolio/internal/jwt"
+	"portfolio/internal/portfolio"
+	"portfolio/web"
+
+	"github.com/gorilla/mux"
+)
+
+func (m *WebModule) endorseSkillEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	m.webService.EndorseSkillFragment(ctx, w, r, mux.Vars(r)["profile_id"])
+}
+
+func (m *WebModule) withdrawEndorsementEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	m.webService.WithdrawEndorsementFragment(ctx, w, vars["profile_id"], vars["endorsement_id"])
+}
+
+func (m *WebModule) recommendProfileEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	m.webService.RecommendProfileFragment(ctx, w, r, mux.Vars(r)["profile_id"])
+}
+
+func (m *WebModule) receivedRecommendationsEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	m.webService.RenderReceivedRecommendations(ctx, w, profileIDFromQuery(r))
+}
+
+func (m *WebModule) approveRecommendationEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	m.webService.ModerateRecommendationFragment(ctx, w, profileIDFromQuery(r), mux.Vars(r)["recommendation_id"], portfolio.RecommendationApproved)
+}
+
+func (m *WebModule) rejectRecommendationEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	m.webService.ModerateRecommendationFragment(ctx, w, profileIDFromQuery(r), mux.Vars(r)["recommendation_id"], portfolio.RecommendationRejected)
+}
+
+func (m *WebModule) deleteRecommendationEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	m.webService.DeleteRecommendationFragment(ctx, w, profileIDFromQuery(r), mux.Vars(r)["recommendation_id"])
+}
+
+// loadPeerFeedback preenche os endossos e as recomendações aprovadas do perfil
+// exibido. São complementares ao perfil: em caso de falha a página é exibida sem eles.
+func (module *WebService) loadPeerFeedback(ctx context.Context, viewData *PageViewData, profile *portfolio.Profile, viewerID string) {
+	endorsements, err := module.portfolioService.GetEndorsements(ctx, profile, viewerID)
+	if err != nil {
+		log.Printf("loadPeerFeedback error fetching endorsements: %v", err)
+	}
+	viewData.Endorsements = endorsements
+
+	recommendations, err := module.portfolioService.GetApprovedRecommendations(ctx, profile.ID)
+	if err != nil {
+		log.Printf("loadPeerFeedback error fetching recommendations: %v", err)
+	}
+	viewData.Recommendations = recommendations
+
+	viewData.CanEndorse = viewerID != "" && viewerID != profile.UserID
+	if viewData.CanEndorse {
+		companies, err := module.portfolioService.RecommendationCompanies(ctx, profile, viewerID)
+		if err != nil {
+			log.Printf("loadPeerFeedback error fetching shared companies: %v", err)
+		}
+		viewData.RecommendationCompanies = companies
+	}
+}
+
+func (module *WebService) EndorseSkillFragment(ctx context.Context, w http.ResponseWriter, r *http.Request, profileID string) {
+	user := jwt.GetUserCurrentUser(ctx)
+
+	if err := r.ParseForm(); err != nil || r.FormValue("skill") == "" {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := module.portfolioService.EndorseSkill(ctx, user.ID, profileID, r.FormValue("skill")); err != nil {
+		writePeerFeedbackError(w, "EndorseSkillFragment", err)
+		return
+	}
+	module.renderPortfolioSkills(ctx, w, profileID)
+}
+
+func (module *WebService) WithdrawEndorsementFragment(ctx context.Context, w http.ResponseWriter, profileID string, endorsementID string) {
+	user := jwt.GetUserCurrentUser(ctx)
+
+	if err := module.portfolioService.WithdrawEndorsement(ctx, user.ID, endorsementID); err != nil {
+		writePeerFeedbackError(w, "WithdrawEndorsementFragment", err)
+		return
+	}
+	module.renderPortfolioSkills(ctx, w, profileID)
+}
+
+// renderPortfolioSkills renderiza a seção de habilidades da página pública com os endossos atualizados
+func (module *WebService) renderPortfolioSkills(ctx context.Context, w http.ResponseWriter, profileID string) {
+	user := jwt.GetUserCurrentUser(ctx)
+
+	profile, err := module.portfolioService.GetVisibleProfile(ctx, profileID, user.ID)
+	if err != nil {
+		writePeerFeedbackError(w, "renderPortfolioSkills", err)
+		return
+	}
+
+	var viewData PageViewData
+	viewData.FromProfile(profile)
+	module.loadPeerFeedback(ctx, &viewData, profile, user.ID)
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	tmpl, err := web.ParseTemplateFragment("components/portfolio_view.html")
+	if err != nil {
+		log.Printf("Error parsing portfolio_view template: %v", err)
+		http.Error(w, "Failed to render template", http.StatusInternalServerError)
+		return
+	}
+	tmpl.ExecuteTemplate(w, "portfolio_skills", viewData)
+}
+
+func (module *WebService) RecommendProfileFragment(ctx context.Context, w http.ResponseWriter, r *http.Request, profileID string) {
+	user := jwt.GetUserCurrentUser(ctx)
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+	input := portfolio.RecommendationInput{
+		Company: r.FormValue("company"),
+		Text:    r.FormValue("text"),
+	}
+
+	message, errorMessage := "Recomendação enviada! Ela aparecerá no perfil depois de aprovada.", ""
+	_, err := module.portfolioService.RecommendProfile(ctx, user.ID, profileID, input)
+	switch {
+	case err == nil:
+	case errors.Is(err, portfolio.ErrRecommendationExists):
+		message = "Você já recomendou este perfil."
+	case errors.Is(err, portfolio.ErrNoSharedExperience):
+		message, errorMessage = "", "Escolha uma empresa em que vocês dois trabalharam."
+	case errors.Is(err, portfolio.ErrInvalidProfileData):
+		message, errorMessage = "", "Escreva a recomendação (até 1000 caracteres)."
+	default:
+		writePeerFeedbackError(w, "RecommendProfileFragment", err)
+		return
+	}
+
+	profile, err := module.portfolioService.GetVisibleProfile(ctx, profileID, user.ID)
+	if err != nil {
+		writePeerFeedbackError(w, "RecommendProfileFragment", err)
+		return
+	}
+	companies, err := module.portfolioService.RecommendationCompanies(ctx, profile, user.ID)
+	if err != nil {
+		writePeerFeedbackError(w, "RecommendProfileFragment", err)
+		return
+	}
+
+	viewData := PageViewData{
+		ProfileID:               profile.ID,
+		CanEndorse:              true,
+		RecommendationCompanies: companies,
+		RecommendationMessage:   message,
+		RecommendationError:     errorMessage,
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	tmpl, err := web.ParseTemplateFragment("components/portfolio_view.html")
+	if err != nil {
+		log.Printf("Error parsing portfolio_view template: %v", err)
+		http.Error(w, "Failed to render template", http.StatusInternalServerError)
+		return
+	}
+	tmpl.ExecuteTemplate(w, "recommendation_form", viewData)
+}
+
+func (module *WebService) RenderReceivedRecommendations(ctx context.Context, w http.ResponseWriter, profileID string) {
+	module.renderReceivedRecommendations(ctx, w, profileID)
+}
+
+func (module *WebService) ModerateRecommendationFragment(ctx context.Context, w http.ResponseWriter, profileID string, recommendationID string, status portfolio.RecommendationStatus) {
+	user := jwt.GetUserCurrentUser(ctx)
+
+	if _, err := module.portfolioService.ModerateRecommendation(ctx, user.ID, recommendationID, status); err != nil {
+		writePeerFeedbackError(w, "ModerateRecommendationFragment", err)
+		return
+	}
+	module.renderReceivedRecommendations(ctx, w, profileID)
+}
+
+func (module *WebService) DeleteRecommendationFragment(ctx context.Context, w http.ResponseWriter, profileID string, recommendationID string) {
+	user := jwt.GetUserCurrentUser(ctx)
+
+	if err := module.portfolioService.DeleteRecommendation(ctx, user.ID, recommendationID); err != nil {
+		writePeerFeedbackError(w, "DeleteRecommendationFragment", err)
+		return
+	}
+	module.renderReceivedRecommendations(ctx, w, profileID)
+}
+
+func (module *WebService) renderReceivedRecommendations(ctx context.Context, w http.ResponseWriter, profileID string) {
+	user := jwt.GetUserCurrentUser(ctx)
+
+	profile, err := module.portfolioService.GetMyProfileByID(ctx, user.ID, profileID)
+	if err != nil {
+		writePeerFeedbackError(w, "renderReceivedRecommendations", err)
+		return
+	}
+	received, err := module.portfolioService.ListReceivedRecommendations(ctx, user.ID, profile.ID, "")
+	if err != nil {
+		writePeerFeedbackError(w, "renderReceivedRecommendations", err)
+		return
+	}
+
+	viewData := PageViewData{
+		ProfileID:               profile.ID,
+		ReceivedRecommendations: received,
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	tmpl, err := web.ParseTemplateFragment("components/recommendations_moderation.html")
+	if err != nil {
+		log.Printf("Error parsing recommendations_moderation template: %v", err)
+		http.Error(w, "Failed to render template", http.StatusInternalServerError)
+		return
+	}
+	tmpl.ExecuteTemplate(w, "recommendations_moderation", viewData)
+}
+
+// writePeerFeedbackError responde os erros de endossos e recomendações com mensagens para a página
+func writePeerFeedbackError(w http.ResponseWriter, operation string, err error) {
+	switch {
+	case errors.Is(err, portfolio.ErrProfileNotFound):
+		http.Error(w, "Perfil não encontrado", http.StatusNotFound)
+	case errors.Is(err, portfolio.ErrProfileRestricted):
+		http.Error(w, "Este perfil é visível apenas para recrutadores autenticados", http.StatusUnauthorized)
+	case errors.Is(err, portfolio.ErrEndorsementNotFound):
+		http.Error(w, "Endosso não encontrado", http.StatusNotFound)
+	case errors.Is(err, portfolio.ErrRecommendationNotFound):
+		http.Error(w, "Recomendação não encontrada", http.StatusNotFound)
+	case errors.Is(err, portfolio.ErrCannotEndorseSelf):
+		http.Error(w, "Você não pode endossar o próprio perfil", http.StatusForbidden)
+	case errors.Is(err, portfolio.ErrSkillNotOnProfile):
+		http.Error(w, "Esta habilidade não está no perfil", http.StatusUnprocessableEntity)
+	default:
+		log.Printf("%s error: %v", operation, err)
+		http.Error(w, "Falha ao processar a solicitação", http.StatusInternalServerError)
+	}
+}
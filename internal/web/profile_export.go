@@ -0,0 +1,135 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"portfolio/internal/export"
+	"portfolio/internal/jwt"
+	"portfolio/internal/portfolio"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+func (m *WebModule) portfolioPDFHandler(w http.ResponseWriter, r *http.Request) {
+	profileID := mux.Vars(r)["profile_id"]
+	pdf, _ := export.Lookup("pdf")
+	m.webService.RenderPortfolioExport(r.Context(), w, r, profileID, pdf)
+}
+
+func (m *WebModule) portfolioPDFBySlugHandler(w http.ResponseWriter, r *http.Request) {
+	profileID, ok := m.webService.resolveSlug(w, r, "/pdf")
+	if !ok {
+		return
+	}
+	pdf, _ := export.Lookup("pdf")
+	m.webService.RenderPortfolioExport(r.Context(), w, r, profileID, pdf)
+}
+
+// exportFormatFromRequest escolhe o formato de exportação da página pública:
+// ?format= tem prioridade sobre o Accept. ok=false indica a página HTML;
+// formato desconhecido em ?format= responde 400 e retorna handled=true.
+func exportFormatFromRequest(w http.ResponseWriter, r *http.Request) (renderer export.Renderer, ok bool, handled bool) {
+	w.Header().Add("Vary", "Accept")
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		renderer, ok = export.Negotiate(r.Header.Get("Accept"))
+		return renderer, ok, false
+	}
+	if strings.EqualFold(format, "html") {
+		return nil, false, false
+	}
+	renderer, ok = export.Lookup(format)
+	if !ok {
+		http.Error(w, "Formato não suportado. Use: html, "+strings.Join(export.Formats(), ", "), http.StatusBadRequest)
+		return nil, false, true
+	}
+	return renderer, true, false
+}
+
+// RenderPortfolioExport gera o currículo no formato pedido (PDF, Markdown, LaTeX
+// ou texto) com o mesmo conteúdo da página de impressão. O arquivo fica em
+// cache enquanto o perfil não for alterado.
+func (module *WebService) RenderPortfolioExport(ctx context.Context, w http.ResponseWriter, r *http.Request, profileID string, renderer export.Renderer) {
+	loggedUser := jwt.GetUserCurrentUser(ctx)
+
+	profile, err := module.portfolioService.GetVisibleProfile(ctx, profileID, loggedUser.ID)
+	if err != nil {
+		if errors.Is(err, portfolio.ErrProfileNotFound) {
+			http.Error(w, "Portfolio não encontrado", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, portfolio.ErrProfileRestricted) {
+			http.Error(w, "Este portfolio é visível apenas para recrutadores autenticados", http.StatusUnauthorized)
+			return
+		}
+		log.Printf("RenderPortfolioExport error: %v", err)
+		http.Error(w, "Falha ao carregar portfolio", http.StatusInternalServerError)
+		return
+	}
+
+	profileOwner, err := module.authService.GetUserByID(ctx, profile.UserID)
+	if err != nil {
+		log.Printf("RenderPortfolioExport error fetching user: %v", err)
+		http.Error(w, "Falha ao carregar dados do usuário", http.StatusInternalServerError)
+		return
+	}
+
+	doc := export.Document{
+		OwnerName: strings.TrimSpace(profileOwner.FirstName + " " + profileOwner.LastName),
+		Profile:   localePreferenceFromRequest(r).localize(w, profile),
+	}
+	key := export.CacheKey(renderer.Name(), doc)
+	etag := `"` + key + `"`
+
+	// Perfis restritos dependem de quem está vendo, então o cache do navegador é privado
+	w.Header().Set("Cache-Control", "private, no-cache")
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Last-Modified", doc.Profile.UpdatedAt.UTC().Format(http.TimeFormat))
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	// Revalidações (304) não contam como download
+	if renderer.Name() == "pdf" {
+		module.recordProfileView(ctx, profile, module.profileVisitFromRequest(r, portfolio.ViewPDF))
+	}
+
+	data, ok := module.exportCache.Get(key)
+	if !ok {
+		var buf bytes.Buffer
+		if err := renderer.Render(&buf, doc); err != nil {
+			log.Printf("RenderPortfolioExport error rendering: %v", err)
+			http.Error(w, "Falha ao gerar arquivo", http.StatusInternalServerError)
+			return
+		}
+		data = buf.Bytes()
+		module.exportCache.Put(key, data)
+	}
+
+	w.Header().Set("Content-Type", export.ContentType(renderer))
+	w.Header().Set("Content-Disposition", `inline; filename="`+exportFileName(doc, renderer)+`"`)
+	http.ServeContent(w, r, "", doc.Profile.UpdatedAt, bytes.NewReader(data))
+}
+
+// exportFileName monta um nome de arquivo ASCII a partir do nome do dono
+func exportFileName(doc export.Document, renderer export.Renderer) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(doc.OwnerName) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == ' ' || r == '-':
+			b.WriteRune('-')
+		}
+	}
+	name := strings.Trim(b.String(), "-")
+	if name == "" {
+		name = "portfolio"
+	}
+	return name + "-" + string(doc.Profile.Locale) + "-" + doc.Profile.UpdatedAt.Format(time.DateOnly) + "." + renderer.Extension()
+}
@@ -7,14 +7,18 @@ import (
 	"io"
 	"log"
 	"net/http"
+	"portfolio/internal/auth"
 	"portfolio/internal/jwt"
 	"portfolio/internal/portfolio"
 	"portfolio/web"
+	"strings"
+
+	"github.com/gorilla/mux"
 )
 
 func (m *WebModule) profilePageEndpoint(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	m.webService.RenderAppPage(ctx, w)
+	m.webService.RenderAppPage(ctx, w, profileIDFromQuery(r))
 }
 
 func (m *WebModule) createProfileEndpoint(w http.ResponseWriter, r *http.Request) {
@@ -24,10 +28,77 @@ func (m *WebModule) createProfileEndpoint(w http.ResponseWriter, r *http.Request
 
 func (m *WebModule) updateProfileEndpoint(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	m.webService.UpdatePortfolioFragment(ctx, w, r)
+	m.webService.UpdatePortfolioFragment(ctx, w, r, profileIDFromQuery(r))
+}
+
+func (m *WebModule) previewProfileEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	m.webService.RenderProfilePreview(ctx, w, r, profileIDFromQuery(r))
+}
+
+func (m *WebModule) publishProfileEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	m.webService.PublishPortfolioFragment(ctx, w, profileIDFromQuery(r))
+}
+
+func (m *WebModule) approvedViewersEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	m.webService.RenderApprovedViewers(ctx, w, profileIDFromQuery(r))
+}
+
+func (m *WebModule) approveViewerEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	m.webService.ApproveViewerFragment(ctx, w, r, profileIDFromQuery(r))
+}
+
+func (m *WebModule) revokeViewerEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	viewerUserID := mux.Vars(r)["user_id"]
+	m.webService.RevokeViewerFragment(ctx, w, profileIDFromQuery(r), viewerUserID)
 }
 
-func (module *WebService) RenderAppPage(ctx context.Context, w io.Writer) error {
+func (m *WebModule) checkSlugEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	m.webService.RenderSlugStatus(ctx, w, profileIDFromQuery(r), r.URL.Query().Get("slug"))
+}
+
+func (m *WebModule) completenessEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	m.webService.RenderCompleteness(ctx, w, profileIDFromQuery(r))
+}
+
+func (m *WebModule) publishStatusEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	m.webService.RenderPublishStatus(ctx, w, profileIDFromQuery(r))
+}
+
+func (m *WebModule) setPrimaryProfileEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	m.webService.SetPrimaryProfileAction(ctx, w, profileIDFromQuery(r))
+}
+
+func (m *WebModule) duplicateProfileEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	m.webService.DuplicateProfileAction(ctx, w, r, profileIDFromQuery(r))
+}
+
+func (m *WebModule) deleteProfileEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	m.webService.DeleteProfileAction(ctx, w, profileIDFromQuery(r))
+}
+
+func (m *WebModule) restoreProfileEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	m.webService.RestoreProfileAction(ctx, w, profileIDFromQuery(r))
+}
+
+// profileIDFromQuery retorna o portfólio selecionado no editor (?profile=).
+// Vazio significa o portfólio principal.
+func profileIDFromQuery(r *http.Request) string {
+	return r.URL.Query().Get("profile")
+}
+
+func (module *WebService) RenderAppPage(ctx context.Context, w io.Writer, profileID string) error {
 	user, err := module.authService.GetUserFromContext(ctx)
 	if err != nil {
 		return err
@@ -44,9 +115,10 @@ func (module *WebService) RenderAppPage(ctx context.Context, w io.Writer) error
 	if user.ProfileImage != nil {
 		viewData.LoggedUserProfileImage = *user.ProfileImage
 	}
+	viewData.HasUploadedAvatar = user.AvatarKey != nil
 
-	// Tenta buscar o portfolio do usuário
-	profile, err := module.portfolioService.GetMyProfile(ctx, user.ID)
+	// Tenta buscar o portfolio selecionado (ou o principal)
+	profile, err := module.portfolioService.GetMyProfileByID(ctx, user.ID, profileID)
 	if err != nil {
 		if !errors.Is(err, portfolio.ErrProfileNotFound) {
 			log.Printf("RenderAppPage error fetching profile: %v", err)
@@ -54,12 +126,58 @@ func (module *WebService) RenderAppPage(ctx context.Context, w io.Writer) error
 		}
 		// Portfolio não existe ainda
 		viewData.ProfileExists = false
+		viewData.DefaultLocale = portfolio.DefaultLocale
+		viewData.SupportedLocales = portfolio.SupportedLocales
 	} else {
 		viewData.ProfileExists = true
 		viewData.FromProfile(profile)
+
+		viewers, err := module.portfolioService.ListApprovedViewers(ctx, user.ID, profile.ID)
+		if err != nil {
+			log.Printf("RenderAppPage error fetching approved viewers: %v", err)
+			return err
+		}
+		viewData.ApprovedViewers = viewers
+		module.loadPeerFeedback(ctx, &viewData, profile, user.ID)
+
+		received, err := module.portfolioService.ListReceivedRecommendations(ctx, user.ID, profile.ID, "")
+		if err != nil {
+			log.Printf("RenderAppPage error fetching recommendations: %v", err)
+			return err
+		}
+		viewData.ReceivedRecommendations = received
+
+		completeness, err := module.portfolioService.GetCompleteness(ctx, user.ID, profile.ID)
+		if err != nil {
+			log.Printf("RenderAppPage error computing completeness: %v", err)
+			return err
+		}
+		viewData.Completeness = completeness
+
+		// As estatísticas são opcionais: uma falha nelas não impede o editor de abrir
+		analytics, err := module.portfolioService.GetProfileAnalytics(ctx, user.ID, profile.ID, portfolio.DefaultAnalyticsDays)
+		if err != nil {
+			log.Printf("RenderAppPage error loading analytics: %v", err)
+		} else {
+			viewData.Analytics = analytics
+		}
+
+		profiles, err := module.portfolioService.ListMyProfiles(ctx, user.ID)
+		if err != nil {
+			log.Printf("RenderAppPage error listing profiles: %v", err)
+			return err
+		}
+		viewData.MyProfiles = profiles
 	}
 
-	tmpl, err := web.ParseTemplate("pages/my_profile.html", "top_bar.html", "portfolio_view.html", "portfolio_editor.html")
+	deleted, err := module.portfolioService.ListDeletedProfiles(ctx, user.ID)
+	if err != nil {
+		log.Printf("RenderAppPage error listing deleted profiles: %v", err)
+		return err
+	}
+	viewData.DeletedProfiles = deleted
+
+	tmpl, err := web.ParseTemplate("pages/my_profile.html", "top_bar.html", "portfolio_view.html", "portfolio_editor.html", "publish_status.html", "approved_viewers.html", "completeness.html", "profile_switcher.html", "recommendations_moderation.html", "deleted_profiles.html", "profile_analytics.html")
 	if err != nil {
 		log.Printf("Error parsing my_profile template: %v", err)
 		return err
@@ -80,8 +198,11 @@ func (module *WebService) CreatePortfolioFragment(ctx context.Context, w http.Re
 
 	profile, err := module.portfolioService.CreateProfile(ctx, userID, input)
 	if err != nil {
-		if errors.Is(err, portfolio.ErrProfileAlreadyExists) {
-			http.Error(w, err.Error(), http.StatusConflict)
+		if errors.Is(err, portfolio.ErrProfileLimitReached) {
+			http.Error(w, "Você atingiu o limite de portfólios", http.StatusConflict)
+			return
+		}
+		if writeValidationErrors(w, err) || writeSlugError(w, err) {
 			return
 		}
 		log.Printf("CreateAndRenderPortfolioHTML error: %v", err)
@@ -89,13 +210,21 @@ func (module *WebService) CreatePortfolioFragment(ctx context.Context, w http.Re
 		return
 	}
 
-	module.renderProfileContent(w, profile)
+	module.renderProfileContent(ctx, w, profile)
 }
 
-func (module *WebService) UpdatePortfolioFragment(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+func (module *WebService) UpdatePortfolioFragment(ctx context.Context, w http.ResponseWriter, r *http.Request, profileID string) {
 	user := jwt.GetUserCurrentUser(ctx)
 	userID := user.ID
 
+	// O editor envia a versão em que a edição foi baseada para não sobrescrever
+	// alterações feitas em outra aba
+	expectedVersion, ok := portfolio.ParseIfMatch(r.Header.Get("If-Match"))
+	if !ok {
+		module.writeVersionConflict(ctx, w, userID, profileID)
+		return
+	}
+
 	var input portfolio.SaveProfileInput
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
 		log.Printf("UpdateAndRenderPortfolioHTML decode error: %v", err)
@@ -103,17 +232,28 @@ func (module *WebService) UpdatePortfolioFragment(ctx context.Context, w http.Re
 		return
 	}
 
-	profile, err := module.portfolioService.UpdateProfile(ctx, userID, input)
+	profile, err := module.portfolioService.UpdateProfile(ctx, userID, profileID, expectedVersion, input)
 	if err != nil {
-		if errors.Is(err, portfolio.ErrProfileNotFound) {
+		if errors.Is(err, portfolio.ErrVersionConflict) {
+			module.writeVersionConflict(ctx, w, userID, profileID)
+			return
+		}
+		// Sem portfólio ainda: o primeiro salvamento cria o principal
+		if errors.Is(err, portfolio.ErrProfileNotFound) && profileID == "" {
 			//http.Error(w, "Profile not found", http.StatusNotFound)
 			profile, err = module.portfolioService.CreateProfile(ctx, userID, input)
 			if err != nil {
+				if writeValidationErrors(w, err) || writeSlugError(w, err) {
+					return
+				}
 				log.Printf("UpdateAndRenderPortfolioHTML create error: %v", err)
 				http.Error(w, "Failed to create profile", http.StatusInternalServerError)
 				return
 			}
-			module.renderProfileContent(w, profile)
+			module.renderProfileContent(ctx, w, profile)
+			return
+		}
+		if writeValidationErrors(w, err) || writeSlugError(w, err) {
 			return
 		}
 		log.Printf("UpdateAndRenderPortfolioHTML error: %v", err)
@@ -121,10 +261,99 @@ func (module *WebService) UpdatePortfolioFragment(ctx context.Context, w http.Re
 		return
 	}
 
-	module.renderProfileContent(w, profile)
+	module.renderProfileContent(ctx, w, profile)
+}
+
+// writeVersionConflict responde 412 com o ETag da versão atual, que o editor usa
+// se o usuário escolher sobrescrever as alterações feitas em outra aba
+func (module *WebService) writeVersionConflict(ctx context.Context, w http.ResponseWriter, userID string, profileID string) {
+	if current, err := module.portfolioService.GetMyProfileByID(ctx, userID, profileID); err == nil {
+		w.Header().Set("ETag", current.ETag())
+	}
+	http.Error(w, "Este portfólio foi alterado em outra aba ou dispositivo", http.StatusPreconditionFailed)
+}
+
+// writeValidationErrors responde 422 com os erros por campo (JSON Pointer) para o
+// editor exibi-los ao lado de cada input
+func writeValidationErrors(w http.ResponseWriter, err error) bool {
+	var verrs portfolio.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return false
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	json.NewEncoder(w).Encode(struct {
+		Error  string                     `json:"error"`
+		Errors portfolio.ValidationErrors `json:"errors"`
+	}{
+		Error:  "Corrija os campos destacados",
+		Errors: verrs,
+	})
+	return true
+}
+
+// writeSlugError responde os erros de validação de slug com a mensagem exibida no editor
+func writeSlugError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, portfolio.ErrSlugTaken):
+		http.Error(w, "Este endereço já está em uso", http.StatusConflict)
+	case errors.Is(err, portfolio.ErrSlugReserved):
+		http.Error(w, "Este endereço é reservado", http.StatusUnprocessableEntity)
+	case errors.Is(err, portfolio.ErrInvalidSlug):
+		http.Error(w, "Use de 3 a 40 letras minúsculas, números ou hífens", http.StatusUnprocessableEntity)
+	default:
+		return false
+	}
+	return true
+}
+
+// RenderSlugStatus responde a checagem de disponibilidade feita pelo editor enquanto o usuário digita
+func (module *WebService) RenderSlugStatus(ctx context.Context, w http.ResponseWriter, profileID string, slug string) {
+	user := jwt.GetUserCurrentUser(ctx)
+	slug = portfolio.NormalizeSlug(slug)
+
+	viewData := struct {
+		Slug      string
+		Available bool
+		Message   string
+	}{Slug: slug}
+
+	err := module.portfolioService.CheckSlugAvailability(ctx, user.ID, profileID, slug)
+	switch {
+	case slug == "":
+		viewData.Message = "Sem endereço personalizado"
+	case err == nil:
+		viewData.Available = true
+		viewData.Message = "Disponível"
+	case errors.Is(err, portfolio.ErrSlugTaken):
+		viewData.Message = "Este endereço já está em uso"
+	case errors.Is(err, portfolio.ErrSlugReserved):
+		viewData.Message = "Este endereço é reservado"
+	case errors.Is(err, portfolio.ErrInvalidSlug):
+		viewData.Message = "Use de 3 a 40 letras minúsculas, números ou hífens"
+	default:
+		log.Printf("RenderSlugStatus error: %v", err)
+		http.Error(w, "Falha ao verificar endereço", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	tmpl, err := web.ParseTemplateFragment("components/slug_status.html")
+	if err != nil {
+		log.Printf("Error parsing slug_status template: %v", err)
+		http.Error(w, "Failed to render template", http.StatusInternalServerError)
+		return
+	}
+	tmpl.ExecuteTemplate(w, "slug_status", viewData)
 }
 
-func (module *WebService) renderProfileContent(w http.ResponseWriter, profile *portfolio.Profile) {
+func (module *WebService) renderProfileContent(ctx context.Context, w http.ResponseWriter, profile *portfolio.Profile) {
+	var viewData PageViewData
+	viewData.FromProfile(profile)
+	module.loadPeerFeedback(ctx, &viewData, profile, profile.UserID)
+
+	// Versão do rascunho recém-salvo: o editor a envia no próximo If-Match
+	w.Header().Set("ETag", profile.ETag())
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	tmpl, err := web.ParseTemplateFragment("components/portfolio_view.html")
 	if err != nil {
@@ -132,5 +361,295 @@ func (module *WebService) renderProfileContent(w http.ResponseWriter, profile *p
 		http.Error(w, "Failed to render template", http.StatusInternalServerError)
 		return
 	}
-	tmpl.ExecuteTemplate(w, "portfolio_view", profile)
+	tmpl.ExecuteTemplate(w, "portfolio_view", viewData)
+}
+
+// RenderProfilePreview renderiza o rascunho do usuário logado com o mesmo layout da página pública
+func (module *WebService) RenderProfilePreview(ctx context.Context, w http.ResponseWriter, r *http.Request, profileID string) {
+	user, err := module.authService.GetUserFromContext(ctx)
+	if err != nil {
+		log.Printf("RenderProfilePreview error fetching user: %v", err)
+		http.Error(w, "Falha ao carregar dados do usuário", http.StatusInternalServerError)
+		return
+	}
+
+	profile, err := module.portfolioService.GetMyProfileByID(ctx, user.ID, profileID)
+	if err != nil {
+		if errors.Is(err, portfolio.ErrProfileNotFound) {
+			http.Redirect(w, r, "/app/profile", http.StatusFound)
+			return
+		}
+		log.Printf("RenderProfilePreview error: %v", err)
+		http.Error(w, "Falha ao carregar perfil", http.StatusInternalServerError)
+		return
+	}
+
+	viewData := PageViewData{
+		Authenticated:       true,
+		PageTitle:           "Pré-visualização",
+		LoggedUserFirstName: user.FirstName,
+		LoggedUserLastName:  user.LastName,
+		OwnerFirstName:      user.FirstName,
+		OwnerLastName:       user.LastName,
+		ProfileExists:       true,
+		IsPreview:           true,
+	}
+
+	if user.ProfileImage != nil {
+		viewData.LoggedUserProfileImage = *user.ProfileImage
+		viewData.OwnerProfileImage = *user.ProfileImage
+	}
+
+	viewData.FromProfile(profile)
+	module.loadPeerFeedback(ctx, &viewData, profile, user.ID)
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	tmpl, err := web.ParseTemplate("pages/show_profile.html", "top_bar.html", "portfolio_view.html")
+	if err != nil {
+		log.Printf("Error parsing show_profile template: %v", err)
+		http.Error(w, "Failed to render template", http.StatusInternalServerError)
+		return
+	}
+	tmpl.ExecuteTemplate(w, "base", viewData)
+}
+
+func (module *WebService) PublishPortfolioFragment(ctx context.Context, w http.ResponseWriter, profileID string) {
+	user := jwt.GetUserCurrentUser(ctx)
+
+	profile, err := module.portfolioService.PublishProfile(ctx, user.ID, profileID)
+	if err != nil {
+		if errors.Is(err, portfolio.ErrProfileNotFound) {
+			http.Error(w, "Perfil não encontrado", http.StatusNotFound)
+			return
+		}
+		log.Printf("PublishPortfolioFragment error: %v", err)
+		http.Error(w, "Falha ao publicar perfil", http.StatusInternalServerError)
+		return
+	}
+
+	module.renderPublishStatus(w, profile)
+}
+
+func (module *WebService) RenderPublishStatus(ctx context.Context, w http.ResponseWriter, profileID string) {
+	user := jwt.GetUserCurrentUser(ctx)
+
+	profile, err := module.portfolioService.GetMyProfileByID(ctx, user.ID, profileID)
+	if err != nil {
+		if errors.Is(err, portfolio.ErrProfileNotFound) {
+			http.Error(w, "Perfil não encontrado", http.StatusNotFound)
+			return
+		}
+		log.Printf("RenderPublishStatus error: %v", err)
+		http.Error(w, "Falha ao carregar perfil", http.StatusInternalServerError)
+		return
+	}
+
+	module.renderPublishStatus(w, profile)
+}
+
+func (module *WebService) renderPublishStatus(w http.ResponseWriter, profile *portfolio.Profile) {
+	var viewData PageViewData
+	viewData.FromProfile(profile)
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	tmpl, err := web.ParseTemplateFragment("components/publish_status.html")
+	if err != nil {
+		log.Printf("Error parsing publish_status template: %v", err)
+		http.Error(w, "Failed to render template", http.StatusInternalServerError)
+		return
+	}
+	tmpl.ExecuteTemplate(w, "publish_status", viewData)
+}
+
+// RenderCompleteness atualiza o checklist depois que o editor salva o perfil
+func (module *WebService) RenderCompleteness(ctx context.Context, w http.ResponseWriter, profileID string) {
+	user := jwt.GetUserCurrentUser(ctx)
+
+	completeness, err := module.portfolioService.GetCompleteness(ctx, user.ID, profileID)
+	if err != nil {
+		if errors.Is(err, portfolio.ErrProfileNotFound) {
+			http.Error(w, "Perfil não encontrado", http.StatusNotFound)
+			return
+		}
+		log.Printf("RenderCompleteness error: %v", err)
+		http.Error(w, "Falha ao calcular preenchimento", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	tmpl, err := web.ParseTemplateFragment("components/completeness.html")
+	if err != nil {
+		log.Printf("Error parsing completeness template: %v", err)
+		http.Error(w, "Failed to render template", http.StatusInternalServerError)
+		return
+	}
+	tmpl.ExecuteTemplate(w, "completeness", completeness)
+}
+
+func (module *WebService) RenderApprovedViewers(ctx context.Context, w http.ResponseWriter, profileID string) {
+	module.renderApprovedViewers(ctx, w, profileID, "")
+}
+
+func (module *WebService) ApproveViewerFragment(ctx context.Context, w http.ResponseWriter, r *http.Request, profileID string) {
+	user := jwt.GetUserCurrentUser(ctx)
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+	email := strings.TrimSpace(r.FormValue("email"))
+	if email == "" {
+		module.renderApprovedViewers(ctx, w, profileID, "Informe o email do usuário")
+		return
+	}
+
+	_, err := module.portfolioService.ApproveViewer(ctx, user.ID, profileID, email)
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrUserNotFound):
+			module.renderApprovedViewers(ctx, w, profileID, "Nenhum usuário encontrado com este email")
+		case errors.Is(err, portfolio.ErrCannotApproveSelf):
+			module.renderApprovedViewers(ctx, w, profileID, "Você não pode aprovar a si mesmo")
+		default:
+			log.Printf("ApproveViewerFragment error: %v", err)
+			http.Error(w, "Falha ao aprovar usuário", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	module.renderApprovedViewers(ctx, w, profileID, "")
+}
+
+func (module *WebService) RevokeViewerFragment(ctx context.Context, w http.ResponseWriter, profileID string, viewerUserID string) {
+	user := jwt.GetUserCurrentUser(ctx)
+
+	if err := module.portfolioService.RevokeViewer(ctx, user.ID, profileID, viewerUserID); err != nil {
+		log.Printf("RevokeViewerFragment error: %v", err)
+		http.Error(w, "Falha ao remover usuário", http.StatusInternalServerError)
+		return
+	}
+
+	module.renderApprovedViewers(ctx, w, profileID, "")
+}
+
+func (module *WebService) renderApprovedViewers(ctx context.Context, w http.ResponseWriter, profileID string, errorMessage string) {
+	user := jwt.GetUserCurrentUser(ctx)
+
+	viewers, err := module.portfolioService.ListApprovedViewers(ctx, user.ID, profileID)
+	if err != nil {
+		if errors.Is(err, portfolio.ErrProfileNotFound) {
+			http.Error(w, "Perfil não encontrado", http.StatusNotFound)
+			return
+		}
+		log.Printf("renderApprovedViewers error: %v", err)
+		http.Error(w, "Falha ao carregar usuários aprovados", http.StatusInternalServerError)
+		return
+	}
+
+	viewData := struct {
+		ProfileID       string
+		ApprovedViewers []portfolio.ApprovedViewer
+		ErrorMessage    string
+	}{
+		ProfileID:       profileID,
+		ApprovedViewers: viewers,
+		ErrorMessage:    errorMessage,
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	tmpl, err := web.ParseTemplateFragment("components/approved_viewers.html")
+	if err != nil {
+		log.Printf("Error parsing approved_viewers template: %v", err)
+		http.Error(w, "Failed to render template", http.StatusInternalServerError)
+		return
+	}
+	tmpl.ExecuteTemplate(w, "approved_viewers", viewData)
+}
+
+// SetPrimaryProfileAction marca o portfólio como principal e recarrega o editor
+func (module *WebService) SetPrimaryProfileAction(ctx context.Context, w http.ResponseWriter, profileID string) {
+	user := jwt.GetUserCurrentUser(ctx)
+
+	profile, err := module.portfolioService.SetPrimaryProfile(ctx, user.ID, profileID)
+	if err != nil {
+		if errors.Is(err, portfolio.ErrProfileNotFound) {
+			http.Error(w, "Perfil não encontrado", http.StatusNotFound)
+			return
+		}
+		log.Printf("SetPrimaryProfileAction error: %v", err)
+		http.Error(w, "Falha ao definir portfólio principal", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("HX-Redirect", "/app/profile?profile="+profile.ID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DuplicateProfileAction cria uma variação do portfólio atual e abre o editor nela
+func (module *WebService) DuplicateProfileAction(ctx context.Context, w http.ResponseWriter, r *http.Request, profileID string) {
+	user := jwt.GetUserCurrentUser(ctx)
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	profile, err := module.portfolioService.DuplicateProfile(ctx, user.ID, profileID, r.FormValue("name"))
+	if err != nil {
+		switch {
+		case errors.Is(err, portfolio.ErrProfileNotFound):
+			http.Error(w, "Perfil não encontrado", http.StatusNotFound)
+		case errors.Is(err, portfolio.ErrProfileLimitReached):
+			http.Error(w, "Você atingiu o limite de portfólios", http.StatusConflict)
+		case errors.Is(err, portfolio.ErrInvalidProfileData):
+			http.Error(w, "Informe um nome de até 100 caracteres", http.StatusUnprocessableEntity)
+		default:
+			log.Printf("DuplicateProfileAction error: %v", err)
+			http.Error(w, "Falha ao criar portfólio", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("HX-Redirect", "/app/profile?profile="+profile.ID)
+	w.WriteHeader(http.StatusCreated)
+}
+
+// DeleteProfileAction move o portfólio para a lixeira e volta para o principal
+func (module *WebService) DeleteProfileAction(ctx context.Context, w http.ResponseWriter, profileID string) {
+	user := jwt.GetUserCurrentUser(ctx)
+
+	if err := module.portfolioService.DeleteProfile(ctx, user.ID, profileID); err != nil {
+		if errors.Is(err, portfolio.ErrProfileNotFound) {
+			http.Error(w, "Perfil não encontrado", http.StatusNotFound)
+			return
+		}
+		log.Printf("DeleteProfileAction error: %v", err)
+		http.Error(w, "Falha ao excluir portfólio", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("HX-Redirect", "/app/profile")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RestoreProfileAction tira o portfólio da lixeira e abre o editor nele
+func (module *WebService) RestoreProfileAction(ctx context.Context, w http.ResponseWriter, profileID string) {
+	user := jwt.GetUserCurrentUser(ctx)
+
+	profile, err := module.portfolioService.RestoreProfile(ctx, user.ID, profileID)
+	if err != nil {
+		switch {
+		case errors.Is(err, portfolio.ErrProfileNotFound):
+			http.Error(w, "Portfólio não encontrado ou prazo de restauração expirado", http.StatusNotFound)
+		case errors.Is(err, portfolio.ErrProfileLimitReached):
+			http.Error(w, "Você atingiu o limite de portfólios; exclua um antes de restaurar", http.StatusConflict)
+		default:
+			log.Printf("RestoreProfileAction error: %v", err)
+			http.Error(w, "Falha ao restaurar portfólio", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("HX-Redirect", "/app/profile?profile="+profile.ID)
+	w.WriteHeader(http.StatusNoContent)
 }
@@ -18,8 +18,23 @@ type ProfileSearchRequest struct {
 	ContractType *[]string                 `json:"contract_type,omitempty"`
 	MinYearsExp  *int                      `json:"min_years_of_experience,omitempty"`
 	MaxYearsExp  *int                      `json:"max_years_of_experience,omitempty"`
-	MinSalary    *float64                  `json:"min_salary,omitempty"`
-	MaxSalary    *float64                  `json:"max_salary,omitempty"`
+	// UseComputedYearsExp filtra pela experiência calculada a partir do histórico
+	UseComputedYearsExp *bool    `json:"use_computed_years_of_experience,omitempty"`
+	MinSalary           *float64 `json:"min_salary,omitempty"`
+	MaxSalary           *float64 `json:"max_salary,omitempty"`
+	MinCompleteness     *int     `json:"min_completeness,omitempty"`
+	Lang                *string  `json:"lang,omitempty"`
+	// Languages são idiomas falados exigidos, no formato "en" ou "en:C1" (nível mínimo)
+	Languages *[]string `json:"languages,omitempty"`
+	// SkillFilters exigem habilidades com nível e/ou tempo de uso mínimos
+	SkillFilters *[]SkillFilter `json:"skill_filters,omitempty"`
+}
+
+// SkillFilter exige uma habilidade, ex: Go pelo menos ADVANCED ou Kubernetes há 3 anos ou mais
+type SkillFilter struct {
+	Name     string               `json:"name"`
+	MinLevel portfolio.SkillLevel `json:"min_level,omitempty"`
+	MinYears int                  `json:"min_years,omitempty"`
 }
 
 func (p *ProfileSearchRequest) ToProfileBuilder() *search.ProfileSearchQueryBuilder {
@@ -59,12 +74,51 @@ func (p *ProfileSearchRequest) ToProfileBuilder() *search.ProfileSearchQueryBuil
 	if p.MaxYearsExp != nil {
 		builder.WithMaxYearsOfExperience(p.MaxYearsExp)
 	}
+	if p.UseComputedYearsExp != nil {
+		builder.WithComputedYearsOfExperience(*p.UseComputedYearsExp)
+	}
 	if p.MinSalary != nil {
 		builder.WithMinSalaryRange(p.MinSalary)
 	}
 	if p.MaxSalary != nil {
 		builder.WithMaxSalaryRange(p.MaxSalary)
 	}
+	if p.MinCompleteness != nil {
+		builder.WithMinCompleteness(p.MinCompleteness)
+	}
+	if p.Lang != nil {
+		if locale, ok := portfolio.ParseLocale(*p.Lang); ok {
+			builder.WithLocale(string(locale))
+		}
+	}
+	if p.Languages != nil {
+		var languages []string
+		for _, l := range *p.Languages {
+			// Valores inválidos são descartados: eles são interpolados no filtro do Meilisearch
+			if filter, ok := portfolio.ParseLanguageFilter(l); ok {
+				languages = append(languages, filter)
+			}
+		}
+		builder.WithLanguages(languages...)
+	}
+	if p.SkillFilters != nil {
+		// Filtros sem nível nem tempo são ignorados: para exigir apenas a
+		// habilidade use o campo skills
+		var levels, years []string
+		for _, f := range *p.SkillFilters {
+			if strings.TrimSpace(f.Name) == "" {
+				continue
+			}
+			if f.MinLevel.IsValid() {
+				levels = append(levels, portfolio.SkillLevelTerm(f.Name, f.MinLevel))
+			}
+			if f.MinYears > 0 {
+				years = append(years, portfolio.SkillYearsTerm(f.Name, f.MinYears))
+			}
+		}
+		builder.WithSkillLevels(levels...)
+		builder.WithSkillYears(years...)
+	}
 	return builder
 }
 
@@ -140,6 +194,10 @@ func extractSearchForm(r *http.Request) search.ProfileSearchQueryBuilder {
 			searchDto.MaxYearsExp = &maxYE
 		}
 	}
+	if useComputed, exists := r.Form["use_computed_years_of_experience"]; exists && len(useComputed) > 0 {
+		uc := useComputed[0] == "true"
+		searchDto.UseComputedYearsExp = &uc
+	}
 	if minSalary, exists := r.Form["min_salary"]; exists && len(minSalary) > 0 && minSalary[0] != "" {
 		var minS float64
 		_, err := fmt.Sscanf(minSalary[0], "%f", &minS)
@@ -154,6 +212,36 @@ func extractSearchForm(r *http.Request) search.ProfileSearchQueryBuilder {
 			searchDto.MaxSalary = &maxS
 		}
 	}
+	if minCompleteness, exists := r.Form["min_completeness"]; exists && len(minCompleteness) > 0 && minCompleteness[0] != "" {
+		var minC int
+		_, err := fmt.Sscanf(minCompleteness[0], "%d", &minC)
+		if err == nil {
+			searchDto.MinCompleteness = &minC
+		}
+	}
+	if lang, exists := r.Form["lang"]; exists && len(lang) > 0 && lang[0] != "" {
+		searchDto.Lang = &lang[0]
+	}
+	// Idioma falado + nível mínimo opcional (ex: inglês C1)
+	if spoken := r.Form.Get("spoken_language"); spoken != "" {
+		if level := r.Form.Get("spoken_language_level"); level != "" {
+			spoken += ":" + level
+		}
+		languages := []string{spoken}
+		searchDto.Languages = &languages
+	}
+	// Habilidade com nível e/ou tempo mínimos (ex: Go avançado, Kubernetes há 3 anos)
+	if name := strings.TrimSpace(r.Form.Get("skill_filter_name")); name != "" {
+		filter := SkillFilter{
+			Name:     name,
+			MinLevel: portfolio.SkillLevel(r.Form.Get("skill_filter_level")),
+		}
+		if minYears := r.Form.Get("skill_filter_years"); minYears != "" {
+			fmt.Sscanf(minYears, "%d", &filter.MinYears)
+		}
+		filters := []SkillFilter{filter}
+		searchDto.SkillFilters = &filters
+	}
 
 	return *searchDto.ToProfileBuilder()
 }
@@ -0,0 +1,76 @@
+package web
+
+import (
+	"context"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"portfolio/internal/jwt"
+	"portfolio/internal/portfolio"
+)
+
+// profileVisitFromRequest extrai da requisição os dados usados para contar a
+// visita: quem está vendo, IP + user agent (só para o hash) e a origem
+func (module *WebService) profileVisitFromRequest(r *http.Request, kind portfolio.ViewKind) portfolio.ProfileVisit {
+	visit := portfolio.ProfileVisit{
+		Kind:         kind,
+		ViewerUserID: jwt.GetUserCurrentUser(r.Context()).ID,
+		ClientIP:     module.clientIP(r),
+		UserAgent:    r.UserAgent(),
+		Source:       portfolio.SourceDirect,
+		At:           time.Now(),
+	}
+
+	referrer, err := url.Parse(r.Referer())
+	if err != nil || referrer.Host == "" {
+		return visit
+	}
+	if strings.EqualFold(referrer.Host, r.Host) {
+		// Navegação interna só importa quando vem da página de busca
+		if strings.HasPrefix(referrer.Path, "/app/search") {
+			visit.Source = portfolio.SourceSearch
+		}
+		return visit
+	}
+	visit.Source = portfolio.SourceReferral
+	visit.ReferrerHost = strings.TrimPrefix(strings.ToLower(referrer.Hostname()), "www.")
+	return visit
+}
+
+// clientIP usa o endereço da conexão. Só quando ela vem de um proxy confiável
+// (TRUSTED_PROXIES) o X-Forwarded-For é lido, da direita para a esquerda, e o
+// primeiro salto que não é um proxy confiável é o visitante; assim o cliente não
+// consegue trocar de IP só mudando o cabeçalho.
+func (module *WebService) clientIP(r *http.Request) string {
+	remote := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(remote); err == nil {
+		remote = host
+	}
+	ip := net.ParseIP(remote)
+	if ip == nil || !module.config.IsTrustedProxy(ip) {
+		return remote
+	}
+
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := net.ParseIP(strings.TrimSpace(hops[i]))
+		if hop == nil {
+			break
+		}
+		if !module.config.IsTrustedProxy(hop) {
+			return hop.String()
+		}
+	}
+	return remote
+}
+
+// recordProfileView conta a visita sem interromper a página em caso de falha
+func (module *WebService) recordProfileView(ctx context.Context, profile *portfolio.Profile, visit portfolio.ProfileVisit) {
+	if err := module.portfolioService.RecordProfileView(ctx, profile, visit); err != nil {
+		log.Printf("recordProfileView error: %v", err)
+	}
+}
@@ -16,23 +16,111 @@ func (m *WebModule) publicProfileHandler(w http.ResponseWriter, r *http.Request)
 	vars := mux.Vars(r)
 	profileID := vars["profile_id"]
 	ctx := r.Context()
-	m.webService.RenderPublicProfilePage(ctx, w, profileID)
+	if renderer, ok, handled := exportFormatFromRequest(w, r); handled {
+		return
+	} else if ok {
+		m.webService.RenderPortfolioExport(ctx, w, r, profileID, renderer)
+		return
+	}
+	m.webService.RenderPublicProfilePage(ctx, w, profileID, localePreferenceFromRequest(r), m.webService.profileVisitFromRequest(r, portfolio.ViewPage))
 }
 
 func (m *WebModule) portfolioPrintHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	profileID := vars["profile_id"]
 	ctx := r.Context()
-	m.webService.RenderPortfolioPrint(ctx, w, profileID)
+	m.webService.RenderPortfolioPrint(ctx, w, profileID, localePreferenceFromRequest(r), m.webService.profileVisitFromRequest(r, portfolio.ViewPrint))
+}
+
+func (m *WebModule) publicProfileBySlugHandler(w http.ResponseWriter, r *http.Request) {
+	profileID, ok := m.webService.resolveSlug(w, r, "")
+	if !ok {
+		return
+	}
+	if renderer, ok, handled := exportFormatFromRequest(w, r); handled {
+		return
+	} else if ok {
+		m.webService.RenderPortfolioExport(r.Context(), w, r, profileID, renderer)
+		return
+	}
+	m.webService.RenderPublicProfilePage(r.Context(), w, profileID, localePreferenceFromRequest(r), m.webService.profileVisitFromRequest(r, portfolio.ViewPage))
+}
+
+func (m *WebModule) portfolioPrintBySlugHandler(w http.ResponseWriter, r *http.Request) {
+	profileID, ok := m.webService.resolveSlug(w, r, "/print")
+	if !ok {
+		return
+	}
+	m.webService.RenderPortfolioPrint(r.Context(), w, profileID, localePreferenceFromRequest(r), m.webService.profileVisitFromRequest(r, portfolio.ViewPrint))
+}
+
+// localePreference guarda o idioma pedido pelo visitante (?lang= e Accept-Language)
+type localePreference struct {
+	lang           string
+	acceptLanguage string
+}
+
+func localePreferenceFromRequest(r *http.Request) localePreference {
+	return localePreference{
+		lang:           r.URL.Query().Get("lang"),
+		acceptLanguage: r.Header.Get("Accept-Language"),
+	}
+}
+
+// localize escolhe o idioma entre os disponíveis no perfil e devolve o perfil traduzido
+func (lp localePreference) localize(w http.ResponseWriter, profile *portfolio.Profile) *portfolio.Profile {
+	locale := portfolio.NegotiateLocale(lp.lang, lp.acceptLanguage, profile.AvailableLocales(), profile.ContentLocale())
+	w.Header().Set("Content-Language", string(locale))
+	w.Header().Add("Vary", "Accept-Language")
+	return profile.Localize(locale)
+}
+
+// resolveSlug converte o slug da URL no ID do perfil. Slugs antigos recebem um
+// redirecionamento permanente para o slug atual (ou para a URL por ID, caso o
+// usuário tenha removido o slug); nesse caso retorna ok=false.
+func (module *WebService) resolveSlug(w http.ResponseWriter, r *http.Request, suffix string) (string, bool) {
+	slug := mux.Vars(r)["slug"]
+
+	profileID, currentSlug, err := module.portfolioService.ResolveSlug(r.Context(), slug)
+	if err != nil {
+		if errors.Is(err, portfolio.ErrProfileNotFound) {
+			http.Error(w, "Perfil não encontrado", http.StatusNotFound)
+			return "", false
+		}
+		log.Printf("resolveSlug error: %v", err)
+		http.Error(w, "Falha ao carregar perfil", http.StatusInternalServerError)
+		return "", false
+	}
+
+	if currentSlug != slug {
+		target := "/app/profile/" + profileID + suffix
+		if currentSlug != "" {
+			target = "/u/" + currentSlug + suffix
+		}
+		// Preserva parâmetros como ?lang=
+		if r.URL.RawQuery != "" {
+			target += "?" + r.URL.RawQuery
+		}
+		http.Redirect(w, r, target, http.StatusMovedPermanently)
+		return "", false
+	}
+	return profileID, true
 }
 
-func (module *WebService) RenderPublicProfilePage(ctx context.Context, w http.ResponseWriter, profileID string) {
-	profile, err := module.portfolioService.GetProfile(ctx, profileID)
+func (module *WebService) RenderPublicProfilePage(ctx context.Context, w http.ResponseWriter, profileID string, pref localePreference, visit portfolio.ProfileVisit) {
+	// Verifica se há usuário logado (visibilidade e top_bar)
+	loggedUser := jwt.GetUserCurrentUser(ctx)
+
+	profile, err := module.portfolioService.GetVisibleProfile(ctx, profileID, loggedUser.ID)
 	if err != nil {
 		if errors.Is(err, portfolio.ErrProfileNotFound) {
 			http.Error(w, "Perfil não encontrado", http.StatusNotFound)
 			return
 		}
+		if errors.Is(err, portfolio.ErrProfileRestricted) {
+			http.Error(w, "Este perfil é visível apenas para recrutadores autenticados", http.StatusUnauthorized)
+			return
+		}
 		log.Printf("RenderPublicProfilePage error: %v", err)
 		http.Error(w, "Falha ao carregar perfil", http.StatusInternalServerError)
 		return
@@ -45,9 +133,6 @@ func (module *WebService) RenderPublicProfilePage(ctx context.Context, w http.Re
 		return
 	}
 
-	// Verifica se há usuário logado para popular top_bar
-	loggedUser := jwt.GetUserCurrentUser(ctx)
-
 	viewData := PageViewData{
 		PageTitle:      profileOwner.FirstName + " " + profileOwner.LastName,
 		OwnerFirstName: profileOwner.FirstName,
@@ -69,7 +154,9 @@ func (module *WebService) RenderPublicProfilePage(ctx context.Context, w http.Re
 		viewData.OwnerProfileImage = *profileOwner.ProfileImage
 	}
 
-	viewData.FromProfile(profile)
+	viewData.FromProfile(pref.localize(w, profile))
+	module.loadPeerFeedback(ctx, &viewData, profile, loggedUser.ID)
+	module.recordProfileView(ctx, profile, visit)
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	tmpl, err := web.ParseTemplate("pages/show_profile.html", "top_bar.html", "portfolio_view.html")
@@ -81,13 +168,19 @@ func (module *WebService) RenderPublicProfilePage(ctx context.Context, w http.Re
 	tmpl.ExecuteTemplate(w, "base", viewData)
 }
 
-func (module *WebService) RenderPortfolioPrint(ctx context.Context, w http.ResponseWriter, profileID string) {
-	profile, err := module.portfolioService.GetProfile(ctx, profileID)
+func (module *WebService) RenderPortfolioPrint(ctx context.Context, w http.ResponseWriter, profileID string, pref localePreference, visit portfolio.ProfileVisit) {
+	loggedUser := jwt.GetUserCurrentUser(ctx)
+
+	profile, err := module.portfolioService.GetVisibleProfile(ctx, profileID, loggedUser.ID)
 	if err != nil {
 		if errors.Is(err, portfolio.ErrProfileNotFound) {
 			http.Error(w, "Portfolio não encontrado", http.StatusNotFound)
 			return
 		}
+		if errors.Is(err, portfolio.ErrProfileRestricted) {
+			http.Error(w, "Este portfolio é visível apenas para recrutadores autenticados", http.StatusUnauthorized)
+			return
+		}
 		log.Printf("RenderPortfolioPrint error: %v", err)
 		http.Error(w, "Falha ao carregar portfolio", http.StatusInternalServerError)
 		return
@@ -111,7 +204,8 @@ func (module *WebService) RenderPortfolioPrint(ctx context.Context, w http.Respo
 		viewData.OwnerProfileImage = *profileOwner.ProfileImage
 	}
 
-	viewData.FromProfile(profile)
+	viewData.FromProfile(pref.localize(w, profile))
+	module.recordProfileView(ctx, profile, visit)
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	tmpl, err := web.ParseTemplateFragment("pages/print_portfolio.html")
@@ -1,6 +1,9 @@
 package web
 
-import "portfolio/internal/portfolio"
+import (
+	"portfolio/internal/portfolio"
+	"time"
+)
 
 type PageViewData struct {
 	Authenticated bool
@@ -9,6 +12,8 @@ type PageViewData struct {
 	LoggedUserFirstName    string
 	LoggedUserLastName     string
 	LoggedUserProfileImage string
+	// Foto enviada pelo usuário (pode ser removida); fotos do OAuth não
+	HasUploadedAvatar bool
 
 	OwnerFirstName    string
 	OwnerLastName     string
@@ -17,22 +22,78 @@ type PageViewData struct {
 
 	ProfileExists bool
 
-	ProfileID         string
-	Headline          string
-	Bio               string
-	Seniority         portfolio.Seniority
-	YearsOfExp        int
-	OpenToWork        bool
-	SalaryExpectation float64
-	Currency          string
-	ContractType      string
-	Location          portfolio.LocationType
-	RemoteOnly        bool
-	Skills            portfolio.StringArray
-	SocialLinks       portfolio.SocialLinks
-	Experiences       portfolio.Experiences
-	Projects          portfolio.Projects
-	Educations        portfolio.Educations
+	// Publicação: o editor trabalha sobre o rascunho, o público vê a versão publicada
+	IsPreview             bool
+	PublishedAt           *time.Time
+	HasUnpublishedChanges bool
+	// Versão do rascunho carregada no editor (enviada em If-Match ao salvar)
+	Version int
+
+	ProfileID  string
+	Slug       string
+	PublicPath string
+	Headline   string
+	Bio        string
+	Seniority  portfolio.Seniority
+	YearsOfExp int
+	// Calculados a partir das experiências
+	ComputedYearsOfExp float64
+	SuggestedSeniority portfolio.Seniority
+	OpenToWork         bool
+	SalaryExpectation  float64
+	Currency           string
+	ContractType       string
+	Location           portfolio.LocationType
+	RemoteOnly         bool
+	Skills             portfolio.Skills
+	SocialLinks        portfolio.SocialLinks
+	Experiences        portfolio.Experiences
+	Projects           portfolio.Projects
+	Educations         portfolio.Educations
+	Certifications     portfolio.Certifications
+	Languages          portfolio.Languages
+	Publications       portfolio.Publications
+	Visibility         portfolio.Visibility
+	FieldPrivacy       portfolio.FieldPrivacy
+
+	// Campos sensíveis liberados para o visualizador atual
+	SalaryVisible   bool
+	ContractVisible bool
+	ApprovedViewers []portfolio.ApprovedViewer
+	// Erro do fragmento de usuários aprovados (vazio na página completa)
+	ErrorMessage string
+
+	// Endossos por habilidade e recomendações aprovadas
+	Endorsements    portfolio.Endorsements
+	Recommendations []portfolio.Recommendation
+	// Usuário logado que não é o dono: pode endossar e recomendar
+	CanEndorse bool
+	// Empresas em comum entre o usuário logado e o dono (habilitam a recomendação)
+	RecommendationCompanies []string
+	// Retorno do envio de uma recomendação
+	RecommendationMessage string
+	RecommendationError   string
+	// Recomendações recebidas, para moderação (apenas na página do dono)
+	ReceivedRecommendations []portfolio.Recommendation
+
+	// Checklist de preenchimento (apenas na página do dono)
+	Completeness *portfolio.Completeness
+	// Estatísticas de visualização do perfil publicado (apenas na página do dono)
+	Analytics *portfolio.ProfileAnalytics
+
+	// Portfólios do usuário logado (seletor do editor)
+	ProfileName string
+	IsPrimary   bool
+	MyProfiles  []*portfolio.Profile
+	// Portfólios na lixeira, ainda dentro do prazo de restauração
+	DeletedProfiles []*portfolio.Profile
+
+	// Idioma em que o conteúdo está sendo exibido e idiomas disponíveis no perfil
+	Locale           portfolio.Locale
+	DefaultLocale    portfolio.Locale
+	AvailableLocales []portfolio.Locale
+	SupportedLocales []portfolio.Locale
+	Translations     portfolio.Translations
 }
 
 // FromProfile popula os campos do portfolio a partir de um Profile
@@ -41,11 +102,17 @@ func (p *PageViewData) FromProfile(profile *portfolio.Profile) {
 		return
 	}
 	p.ProfileID = profile.ID
+	p.ProfileName = profile.Name
+	p.IsPrimary = profile.IsPrimary
+	p.Slug = profile.Slug
+	p.PublicPath = profile.PublicPath()
 	p.OwnerId = profile.UserID
 	p.Headline = profile.Headline
 	p.Bio = profile.Bio
 	p.Seniority = profile.Seniority
 	p.YearsOfExp = profile.YearsOfExp
+	p.ComputedYearsOfExp = profile.ComputedYearsOfExp
+	p.SuggestedSeniority = profile.SuggestedSeniority
 	p.OpenToWork = profile.OpenToWork
 	p.SalaryExpectation = profile.SalaryExpectation
 	p.Currency = profile.Currency
@@ -57,6 +124,24 @@ func (p *PageViewData) FromProfile(profile *portfolio.Profile) {
 	p.Experiences = profile.Experiences
 	p.Projects = profile.Projects
 	p.Educations = profile.Educations
+	p.Certifications = profile.Certifications
+	p.Languages = profile.Languages
+	p.Publications = profile.Publications
+	p.Visibility = profile.Visibility
+	p.FieldPrivacy = profile.FieldPrivacy
+	p.SalaryVisible = !profile.IsRedacted(portfolio.FieldGroupSalary)
+	p.ContractVisible = !profile.IsRedacted(portfolio.FieldGroupContract)
+	p.PublishedAt = profile.PublishedAt
+	p.HasUnpublishedChanges = profile.HasUnpublishedChanges()
+	p.Version = profile.Version
+	p.Locale = profile.Locale
+	if p.Locale == "" {
+		p.Locale = profile.ContentLocale()
+	}
+	p.DefaultLocale = profile.ContentLocale()
+	p.AvailableLocales = profile.AvailableLocales()
+	p.SupportedLocales = portfolio.SupportedLocales
+	p.Translations = profile.Translations
 }
 
 // PublicProfileView é mantido para compatibilidade (deprecated)
@@ -3,9 +3,11 @@ package web
 import (
 	"net/http"
 	"portfolio/internal/auth"
+	"portfolio/internal/config"
 	"portfolio/internal/jwt"
 	"portfolio/internal/portfolio"
 	"portfolio/internal/search"
+	"portfolio/internal/taxonomy"
 	"portfolio/web"
 
 	"github.com/gorilla/mux"
@@ -18,13 +20,13 @@ type WebModule struct {
 	webService       *WebService
 }
 
-func NewWebModule(authService *auth.AuthService, jwtService *jwt.JWTService, portfolioService *portfolio.PortfolioService, searchService search.SearchService) *WebModule {
+func NewWebModule(authService *auth.AuthService, jwtService *jwt.JWTService, portfolioService *portfolio.PortfolioService, searchService search.SearchService, taxonomy *taxonomy.TaxonomyService, cfg *config.Config) *WebModule {
 	return &WebModule{
 		authService:      authService,
 		jwtService:       jwtService,
 		portfolioService: portfolioService,
 
-		webService: NewWebService(authService, portfolioService, searchService),
+		webService: NewWebService(authService, portfolioService, searchService, taxonomy, cfg),
 	}
 }
 
@@ -41,14 +43,41 @@ func (m *WebModule) SetupFrontEnd(router *mux.Router) {
 	router.HandleFunc("/app/profile", m.requireAuth(m.profilePageEndpoint)).Methods("GET")
 	router.HandleFunc("/app/profile", m.requireAuth(m.createProfileEndpoint)).Methods("POST")
 	router.HandleFunc("/app/profile", m.requireAuth(m.updateProfileEndpoint)).Methods("PUT")
+	router.HandleFunc("/app/profile", m.requireAuth(m.deleteProfileEndpoint)).Methods("DELETE")
+	router.HandleFunc("/app/profile/primary", m.requireAuth(m.setPrimaryProfileEndpoint)).Methods("POST")
+	router.HandleFunc("/app/profile/duplicate", m.requireAuth(m.duplicateProfileEndpoint)).Methods("POST")
+	router.HandleFunc("/app/profile/restore", m.requireAuth(m.restoreProfileEndpoint)).Methods("POST")
+	router.HandleFunc("/app/profile/preview", m.requireAuth(m.previewProfileEndpoint)).Methods("GET")
+	router.HandleFunc("/app/profile/publish", m.requireAuth(m.publishProfileEndpoint)).Methods("POST")
+	router.HandleFunc("/app/profile/publish", m.requireAuth(m.publishStatusEndpoint)).Methods("GET")
+	router.HandleFunc("/app/profile/viewers", m.requireAuth(m.approvedViewersEndpoint)).Methods("GET")
+	router.HandleFunc("/app/profile/viewers", m.requireAuth(m.approveViewerEndpoint)).Methods("POST")
+	router.HandleFunc("/app/profile/viewers/{user_id}", m.requireAuth(m.revokeViewerEndpoint)).Methods("DELETE")
+	router.HandleFunc("/app/profile/completeness", m.requireAuth(m.completenessEndpoint)).Methods("GET")
+	router.HandleFunc("/app/profile/slug/check", m.requireAuth(m.checkSlugEndpoint)).Methods("GET")
+	router.HandleFunc("/app/profile/recommendations", m.requireAuth(m.receivedRecommendationsEndpoint)).Methods("GET")
+	router.HandleFunc("/app/profile/recommendations/{recommendation_id}/approve", m.requireAuth(m.approveRecommendationEndpoint)).Methods("POST")
+	router.HandleFunc("/app/profile/recommendations/{recommendation_id}/reject", m.requireAuth(m.rejectRecommendationEndpoint)).Methods("POST")
+	router.HandleFunc("/app/profile/recommendations/{recommendation_id}", m.requireAuth(m.deleteRecommendationEndpoint)).Methods("DELETE")
 
 	// Página de Busca
 	router.HandleFunc("/app/search", m.optionalAuth(m.searchPageEndpoint)).Methods("GET")
 	router.HandleFunc("/app/search/results", m.searchResultHandler).Methods("GET")
+	router.HandleFunc("/app/skills/suggest", m.skillSuggestEndpoint).Methods("GET")
 
 	// Página pública de visualização de perfil
 	router.HandleFunc("/app/profile/{profile_id}", m.optionalAuth(m.publicProfileHandler)).Methods("GET")
-	router.HandleFunc("/app/profile/{profile_id}/print", m.portfolioPrintHandler).Methods("GET")
+	router.HandleFunc("/app/profile/{profile_id}/print", m.optionalAuth(m.portfolioPrintHandler)).Methods("GET")
+	router.HandleFunc("/app/profile/{profile_id}/pdf", m.optionalAuth(m.portfolioPDFHandler)).Methods("GET")
+	// Endossos e recomendações feitos por quem visita o perfil
+	router.HandleFunc("/app/profile/{profile_id}/endorsements", m.requireAuth(m.endorseSkillEndpoint)).Methods("POST")
+	router.HandleFunc("/app/profile/{profile_id}/endorsements/{endorsement_id}", m.requireAuth(m.withdrawEndorsementEndpoint)).Methods("DELETE")
+	router.HandleFunc("/app/profile/{profile_id}/recommendations", m.requireAuth(m.recommendProfileEndpoint)).Methods("POST")
+
+	// URLs amigáveis (slug escolhido pelo usuário)
+	router.HandleFunc("/u/{slug}", m.optionalAuth(m.publicProfileBySlugHandler)).Methods("GET")
+	router.HandleFunc("/u/{slug}/print", m.optionalAuth(m.portfolioPrintBySlugHandler)).Methods("GET")
+	router.HandleFunc("/u/{slug}/pdf", m.optionalAuth(m.portfolioPDFBySlugHandler)).Methods("GET")
 
 }
 
@@ -14,6 +14,8 @@ func (m *WebModule) searchPageEndpoint(w http.ResponseWriter, r *http.Request) {
 
 func (m *WebModule) searchResultHandler(w http.ResponseWriter, r *http.Request) {
 	searchQuery := extractSearchForm(r)
+	// "Go" também encontra perfis indexados com "golang", "go lang" etc.
+	searchQuery.WithSkillAliases(m.webService.taxonomy.Expand)
 	ctx := r.Context()
 	RenderPortfolioSearchResults(ctx, w, searchQuery, m.webService.searchService)
 }
@@ -0,0 +1,73 @@
+package web
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"portfolio/internal/search"
+)
+
+const (
+	defaultSkillSuggestions = 8
+	maxSkillSuggestions     = 20
+	// A contagem por habilidade muda devagar; evita uma consulta ao Meili por tecla
+	skillFrequenciesTTL = 5 * time.Minute
+)
+
+// skillSuggestEndpoint responde GET /app/skills/suggest?q=&limit= com as
+// habilidades da taxonomia e do índice que correspondem ao texto digitado
+func (m *WebModule) skillSuggestEndpoint(w http.ResponseWriter, r *http.Request) {
+	limit := defaultSkillSuggestions
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = min(parsed, maxSkillSuggestions)
+	}
+
+	suggestions := m.webService.taxonomy.Suggest(r.URL.Query().Get("q"), m.webService.skillFrequencies.Get(), limit)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=60")
+	json.NewEncoder(w).Encode(suggestions)
+}
+
+// skillFrequencyCache guarda por alguns minutos quantos perfis usam cada habilidade
+type skillFrequencyCache struct {
+	searchService search.SearchService
+
+	mu          sync.Mutex
+	frequencies map[string]int64
+	expiresAt   time.Time
+}
+
+func newSkillFrequencyCache(searchService search.SearchService) *skillFrequencyCache {
+	return &skillFrequencyCache{searchService: searchService}
+}
+
+// Get retorna a contagem em cache ou consulta o índice. Se o Meili estiver
+// indisponível, mantém a última contagem (ou nenhuma) e as sugestões vêm só da taxonomia.
+func (c *skillFrequencyCache) Get() map[string]int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if time.Now().Before(c.expiresAt) {
+		return c.frequencies
+	}
+
+	frequencies, err := c.searchService.SkillFrequencies()
+	if err != nil {
+		log.Printf("SkillFrequencies error: %v", err)
+		// Tenta de novo só depois de um intervalo curto
+		c.expiresAt = time.Now().Add(skillFrequenciesTTL / 5)
+		return c.frequencies
+	}
+	c.frequencies = frequencies
+	c.expiresAt = time.Now().Add(skillFrequenciesTTL)
+	return frequencies
+}
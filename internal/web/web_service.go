@@ -2,22 +2,36 @@ package web
 
 import (
 	"portfolio/internal/auth"
+	"portfolio/internal/config"
+	"portfolio/internal/export"
 	"portfolio/internal/portfolio"
 	"portfolio/internal/search"
+	"portfolio/internal/taxonomy"
 )
 
 type WebService struct {
 	authService      *auth.AuthService
 	portfolioService *portfolio.PortfolioService
 	searchService    search.SearchService
+	taxonomy         *taxonomy.TaxonomyService
+	// Documentos já renderizados (PDF), indexados por perfil + UpdatedAt
+	exportCache *export.Cache
+	// Quantos perfis usam cada habilidade, para ordenar as sugestões
+	skillFrequencies *skillFrequencyCache
+	// Proxies confiáveis, usados para descobrir o IP do visitante
+	config *config.Config
 }
 
 
-func NewWebService(authService *auth.AuthService, portfolioService *portfolio.PortfolioService, searchService search.SearchService) *WebService {
+func NewWebService(authService *auth.AuthService, portfolioService *portfolio.PortfolioService, searchService search.SearchService, taxonomy *taxonomy.TaxonomyService, cfg *config.Config) *WebService {
 	return &WebService{
 		authService:      authService,
 		portfolioService: portfolioService,
 		searchService:    searchService,
+		taxonomy:         taxonomy,
+		exportCache:      export.NewCache(export.DefaultCacheEntries),
+		skillFrequencies: newSkillFrequencyCache(searchService),
+		config:           cfg,
 	}
 }
 
@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Versão publicada do portfolio. As colunas "normais" passam a ser o rascunho.
+ALTER TABLE profiles ADD COLUMN published_data JSONB DEFAULT NULL;
+ALTER TABLE profiles ADD COLUMN published_at TIMESTAMPTZ DEFAULT NULL;
+
+-- Perfis existentes já eram públicos: publica o estado atual de cada um
+UPDATE profiles SET
+    published_data = jsonb_build_object(
+        'id', id,
+        'userId', user_id,
+        'headline', COALESCE(headline, ''),
+        'bio', COALESCE(bio, ''),
+        'seniority', COALESCE(seniority, ''),
+        'yearsOfExperience', COALESCE(years_of_experience, 0),
+        'openToWork', COALESCE(open_to_work, FALSE),
+        'salaryExpectation', COALESCE(salary_expectation, 0),
+        'currency', COALESCE(currency, ''),
+        'contractType', COALESCE(contract_type, ''),
+        'location', COALESCE(location, ''),
+        'remoteOnly', COALESCE(remote_only, FALSE),
+        'skills', COALESCE(skills, '[]'::jsonb),
+        'socialLinks', COALESCE(social_links, '{}'::jsonb),
+        'experiences', COALESCE(experiences, '[]'::jsonb),
+        'projects', COALESCE(projects, '[]'::jsonb),
+        'educations', COALESCE(educations, '[]'::jsonb),
+        'createdAt', created_at,
+        'updatedAt', updated_at
+    ),
+    published_at = updated_at;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE profiles DROP COLUMN published_at;
+ALTER TABLE profiles DROP COLUMN published_data;
+-- +goose StatementEnd
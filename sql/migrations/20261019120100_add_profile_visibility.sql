@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Visibilidade do perfil: PUBLIC, UNLISTED, PRIVATE ou RECRUITERS_ONLY
+ALTER TABLE profiles ADD COLUMN visibility VARCHAR(20) NOT NULL DEFAULT 'PUBLIC';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE profiles DROP COLUMN visibility;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Privacidade por campo (ex: {"salary": "AUTHENTICATED", "contract": "APPROVED"})
+ALTER TABLE profiles ADD COLUMN field_privacy JSONB DEFAULT '{}'::jsonb;
+
+-- Usuários aprovados pelo candidato para ver campos com audiência "APPROVED"
+CREATE TABLE profile_approved_viewers (
+    profile_id UUID NOT NULL,
+    viewer_user_id UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (profile_id, viewer_user_id),
+    CONSTRAINT fk_approved_viewer_profile FOREIGN KEY(profile_id) REFERENCES profiles(id) ON DELETE CASCADE,
+    CONSTRAINT fk_approved_viewer_user FOREIGN KEY(viewer_user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS profile_approved_viewers;
+ALTER TABLE profiles DROP COLUMN field_privacy;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Slug escolhido pelo usuário para a URL pública (/u/{slug})
+ALTER TABLE profiles ADD COLUMN slug VARCHAR(40);
+CREATE UNIQUE INDEX uq_profiles_slug ON profiles (slug) WHERE slug IS NOT NULL;
+
+-- Slugs antigos continuam redirecionando (301) para o slug atual do perfil
+CREATE TABLE profile_slug_history (
+    slug VARCHAR(40) PRIMARY KEY,
+    profile_id UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_slug_history_profile FOREIGN KEY(profile_id) REFERENCES profiles(id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS profile_slug_history;
+DROP INDEX IF EXISTS uq_profiles_slug;
+ALTER TABLE profiles DROP COLUMN slug;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Permite vários portfólios por usuário (ex: "Backend" e "Dados"), com um principal
+ALTER TABLE profiles DROP CONSTRAINT uq_user_profile;
+ALTER TABLE profiles ADD COLUMN name VARCHAR(100) NOT NULL DEFAULT 'Principal';
+ALTER TABLE profiles ADD COLUMN is_primary BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Perfis existentes eram únicos por usuário, logo todos são principais
+UPDATE profiles SET is_primary = TRUE;
+
+CREATE INDEX idx_profiles_user_id ON profiles (user_id);
+CREATE UNIQUE INDEX uq_profiles_primary ON profiles (user_id) WHERE is_primary;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS uq_profiles_primary;
+DROP INDEX IF EXISTS idx_profiles_user_id;
+DELETE FROM profiles WHERE is_primary = FALSE;
+ALTER TABLE profiles DROP COLUMN is_primary;
+ALTER TABLE profiles DROP COLUMN name;
+ALTER TABLE profiles ADD CONSTRAINT uq_user_profile UNIQUE(user_id);
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Idioma em que os campos principais foram escritos
+ALTER TABLE profiles ADD COLUMN default_locale VARCHAR(10) NOT NULL DEFAULT 'pt-BR';
+
+-- Traduções dos campos de texto por idioma
+-- (ex: {"en": {"headline": "...", "bio": "...", "experiences": ["..."], "projects": ["..."]}})
+ALTER TABLE profiles ADD COLUMN translations JSONB NOT NULL DEFAULT '{}'::jsonb;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE profiles DROP COLUMN translations;
+ALTER TABLE profiles DROP COLUMN default_locale;
+-- +goose StatementEnd
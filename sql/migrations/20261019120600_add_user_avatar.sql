@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Versão do avatar enviado pelo usuário; os arquivos ficam no BlobStore em
+-- avatars/{user_id}/{versão}/ e profile_image aponta para a URL estável /media/avatars/{user_id}
+ALTER TABLE users ADD COLUMN avatar_key VARCHAR(64);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP COLUMN avatar_key;
+-- +goose StatementEnd
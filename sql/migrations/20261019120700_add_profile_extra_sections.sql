@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Certificações, idiomas falados (nível CEFR) e publicações/palestras
+ALTER TABLE profiles ADD COLUMN certifications JSONB NOT NULL DEFAULT '[]'::jsonb;
+ALTER TABLE profiles ADD COLUMN languages JSONB NOT NULL DEFAULT '[]'::jsonb;
+ALTER TABLE profiles ADD COLUMN publications JSONB NOT NULL DEFAULT '[]'::jsonb;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE profiles DROP COLUMN publications;
+ALTER TABLE profiles DROP COLUMN languages;
+ALTER TABLE profiles DROP COLUMN certifications;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Taxonomia de habilidades: nome canônico, apelidos e categoria. name_key é o
+-- nome normalizado (minúsculas, sem espaços, hífens, pontos ou sublinhados)
+-- usado para evitar duplicatas como "Node.js" e "nodejs".
+CREATE TABLE skill_taxonomy (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    name_key VARCHAR(100) NOT NULL UNIQUE,
+    category VARCHAR(20) NOT NULL,
+    aliases JSONB NOT NULL DEFAULT '[]'::jsonb,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS skill_taxonomy;
+-- +goose StatementEnd
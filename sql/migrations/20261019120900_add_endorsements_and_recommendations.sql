@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Endosso de uma habilidade do perfil por outro usuário (um por habilidade)
+CREATE TABLE skill_endorsements (
+    id UUID PRIMARY KEY,
+    profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    endorser_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    skill VARCHAR(100) NOT NULL,     -- Nome canônico da habilidade (ex: 'Go')
+    skill_key VARCHAR(100) NOT NULL, -- Nome normalizado para comparação (ex: 'go')
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_skill_endorsement UNIQUE (profile_id, endorser_user_id, skill_key)
+);
+
+CREATE INDEX idx_skill_endorsements_profile ON skill_endorsements(profile_id);
+
+-- Recomendação escrita por um colega da mesma empresa. Só aparece no perfil
+-- depois de aprovada pelo dono: PENDING, APPROVED ou REJECTED
+CREATE TABLE profile_recommendations (
+    id UUID PRIMARY KEY,
+    profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    author_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    company VARCHAR(255) NOT NULL,   -- Empresa em comum
+    author_role VARCHAR(255) NOT NULL DEFAULT '', -- Cargo do autor nessa empresa
+    body TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_profile_recommendation UNIQUE (profile_id, author_user_id)
+);
+
+CREATE INDEX idx_profile_recommendations_profile ON profile_recommendations(profile_id, status);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS profile_recommendations;
+DROP TABLE IF EXISTS skill_endorsements;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Controle de concorrência otimista: cada atualização do rascunho incrementa a
+-- versão, e salvamentos baseados em uma versão antiga são recusados.
+ALTER TABLE profiles ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE profiles DROP COLUMN IF EXISTS version;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Exclusão reversível: o portfólio some de todas as leituras e pode ser
+-- restaurado por 30 dias, depois é removido pela rotina de limpeza.
+ALTER TABLE profiles ADD COLUMN deleted_at TIMESTAMPTZ;
+CREATE INDEX idx_profiles_deleted_at ON profiles (deleted_at) WHERE deleted_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM profiles WHERE deleted_at IS NOT NULL;
+DROP INDEX IF EXISTS idx_profiles_deleted_at;
+ALTER TABLE profiles DROP COLUMN IF EXISTS deleted_at;
+-- +goose StatementEnd
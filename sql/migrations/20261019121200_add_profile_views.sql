@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Visitas ao perfil publicado, uma por visitante por dia e por tipo
+-- (VIEW = página, PRINT = versão para impressão, PDF = download).
+-- visitor_hash é um HMAC do IP + user agent com o dia: o IP não é guardado e o
+-- mesmo visitante não pode ser ligado entre dias diferentes.
+CREATE TABLE profile_views (
+    profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    kind VARCHAR(10) NOT NULL,
+    day DATE NOT NULL,
+    visitor_hash CHAR(64) NOT NULL,
+    source VARCHAR(10) NOT NULL DEFAULT 'DIRECT', -- DIRECT, SEARCH ou REFERRAL
+    referrer_host VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (profile_id, kind, day, visitor_hash)
+);
+
+CREATE INDEX idx_profile_views_profile_day ON profile_views(profile_id, day);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS profile_views;
+-- +goose StatementEnd
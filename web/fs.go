@@ -5,6 +5,7 @@ import (
 	"html/template"
 	"io/fs"
 	"net/http"
+	"portfolio/internal/portfolio"
 	"strings"
 	"time"
 )
@@ -32,9 +33,22 @@ var templateFuncs = template.FuncMap{
 		}
 		return t.Format("2006-01-02")
 	},
+	"formatDateTimePtr": func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.Format("02/01/2006 15:04")
+	},
 	"join": func(sep string, arr []string) string {
 		return strings.Join(arr, sep)
 	},
+	// itemAt retorna arr[i] ou vazio quando o índice não existe
+	"itemAt": func(arr []string, i int) string {
+		if i < 0 || i >= len(arr) {
+			return ""
+		}
+		return arr[i]
+	},
 	"seniorityLabel": func(s string) string {
 		labels := map[string]string{
 			"JUNIOR":    "Júnior",
@@ -60,6 +74,28 @@ var templateFuncs = template.FuncMap{
 		}
 		return l
 	},
+	"visibilityLabel": func(v string) string {
+		labels := map[string]string{
+			"PUBLIC":          "Público",
+			"UNLISTED":        "Não listado",
+			"PRIVATE":         "Privado",
+			"RECRUITERS_ONLY": "Apenas recrutadores",
+		}
+		if label, ok := labels[v]; ok {
+			return label
+		}
+		return labels["PUBLIC"]
+	},
+	// Idiomas e níveis CEFR dos seletores de idiomas falados
+	"spokenLanguages": portfolio.SpokenLanguageOptions,
+	"languageLevels": func() []portfolio.LanguageLevel {
+		return portfolio.LanguageLevels
+	},
+	// Níveis de proficiência das habilidades
+	"skillLevels": func() []portfolio.SkillLevel {
+		return portfolio.SkillLevels
+	},
+	"now": time.Now,
 	"currentDate": func() string {
 		return time.Now().Format("02/01/2006")
 	},
@@ -35,3 +35,68 @@ function showSection(sectionId) {
         }
     });
 }
+
+// Sugestões de habilidades (GET /app/skills/suggest) para inputs com o atributo
+// data-skill-suggest. Com data-skill-suggest="multiple" o input aceita várias
+// habilidades separadas por vírgula e a sugestão completa apenas a última.
+const skillSuggestTimers = new WeakMap();
+let skillSuggestLists = 0;
+
+document.addEventListener('input', event => {
+    const input = event.target;
+    if (!(input instanceof HTMLInputElement) || !input.hasAttribute('data-skill-suggest')) {
+        return;
+    }
+    clearTimeout(skillSuggestTimers.get(input));
+    skillSuggestTimers.set(input, setTimeout(() => suggestSkills(input), 200));
+});
+
+function suggestSkills(input) {
+    const term = currentSkillTerm(input);
+    const list = skillSuggestionList(input);
+    if (!term.text) {
+        list.replaceChildren();
+        return;
+    }
+
+    fetch('/app/skills/suggest?q=' + encodeURIComponent(term.text))
+        .then(response => response.ok ? response.json() : [])
+        .then(suggestions => {
+            // Ignora respostas atrasadas se o usuário continuou digitando
+            if (currentSkillTerm(input).text !== term.text) {
+                return;
+            }
+            list.replaceChildren(...suggestions.map(suggestion => {
+                const option = document.createElement('option');
+                option.value = term.prefix + suggestion.name;
+                const details = [];
+                if (suggestion.alias) details.push(suggestion.alias + ' → ' + suggestion.name);
+                if (suggestion.count) details.push(suggestion.count + (suggestion.count === 1 ? ' perfil' : ' perfis'));
+                option.label = details.join(' · ');
+                return option;
+            }));
+        })
+        .catch(error => console.error('Skill suggestions failed:', error));
+}
+
+// currentSkillTerm separa o texto sendo digitado do que já foi preenchido antes dele
+function currentSkillTerm(input) {
+    if (input.dataset.skillSuggest !== 'multiple') {
+        return { prefix: '', text: input.value.trim() };
+    }
+    const parts = input.value.split(',');
+    const text = parts.pop().trim();
+    return { prefix: parts.length ? parts.join(',') + ', ' : '', text };
+}
+
+// skillSuggestionList cria sob demanda o datalist ligado ao input
+function skillSuggestionList(input) {
+    if (input.list) {
+        return input.list;
+    }
+    const list = document.createElement('datalist');
+    list.id = 'skill-suggestions-' + (++skillSuggestLists);
+    input.insertAdjacentElement('afterend', list);
+    input.setAttribute('list', list.id);
+    return list;
+}
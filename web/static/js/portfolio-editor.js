@@ -156,6 +156,17 @@ function addProject(data = null) {
                 <label class="block text-sm font-medium text-gray-700 mb-1">URL Demo</label>
                 <input type="url" data-field="liveUrl" value="${liveUrl}" class="w-full p-2 border border-gray-300 rounded-lg">
             </div>
+            <div class="md:col-span-2">
+                <label class="block text-sm font-medium text-gray-700 mb-1">Galeria</label>
+                <div class="media-list space-y-2">${(data && data.media ? data.media : []).map(mediaItemHtml).join('')}</div>
+                <div class="flex gap-2 mt-2">
+                    <label class="bg-gray-100 text-gray-700 px-3 py-1.5 rounded-lg hover:bg-gray-200 text-sm cursor-pointer">
+                        + Imagem
+                        <input type="file" accept="image/jpeg,image/png,image/gif,image/webp" class="hidden" onchange="uploadProjectImage(this)">
+                    </label>
+                    <button type="button" onclick="addProjectVideo(this)" class="bg-gray-100 text-gray-700 px-3 py-1.5 rounded-lg hover:bg-gray-200 text-sm">+ Vídeo (link)</button>
+                </div>
+            </div>
         </div>
         <button type="button" onclick="removeItem(this, 'project')" class="mt-2 text-red-600 hover:text-red-800 text-sm">🗑️ Remover</button>
     </div>
@@ -195,18 +206,132 @@ function addEducation() {
     container.insertAdjacentHTML('beforeend', html);
 }
 
+function addCertification() {
+    const container = document.getElementById('certifications-container');
+    const html = `
+    <div class="certification-item border border-gray-200 rounded-lg p-4">
+        <div class="grid grid-cols-1 md:grid-cols-2 gap-4">
+            <div>
+                <label class="block text-sm font-medium text-gray-700 mb-1">Nome</label>
+                <input type="text" data-field="name" class="w-full p-2 border border-gray-300 rounded-lg" placeholder="AWS Certified Solutions Architect">
+            </div>
+            <div>
+                <label class="block text-sm font-medium text-gray-700 mb-1">Emissor</label>
+                <input type="text" data-field="issuer" class="w-full p-2 border border-gray-300 rounded-lg" placeholder="Amazon Web Services">
+            </div>
+            <div>
+                <label class="block text-sm font-medium text-gray-700 mb-1">ID da credencial</label>
+                <input type="text" data-field="credentialId" class="w-full p-2 border border-gray-300 rounded-lg">
+            </div>
+            <div>
+                <label class="block text-sm font-medium text-gray-700 mb-1">URL de verificação</label>
+                <input type="url" data-field="verificationUrl" class="w-full p-2 border border-gray-300 rounded-lg">
+            </div>
+            <div>
+                <label class="block text-sm font-medium text-gray-700 mb-1">Emissão</label>
+                <input type="date" data-field="issueDate" class="w-full p-2 border border-gray-300 rounded-lg">
+            </div>
+            <div>
+                <label class="block text-sm font-medium text-gray-700 mb-1">Validade (vazio = não expira)</label>
+                <input type="date" data-field="expiryDate" class="w-full p-2 border border-gray-300 rounded-lg">
+            </div>
+        </div>
+        <button type="button" onclick="removeItem(this, 'certification')" class="mt-2 text-red-600 hover:text-red-800 text-sm">🗑️ Remover</button>
+    </div>
+`;
+    container.insertAdjacentHTML('beforeend', html);
+}
+
+// As opções de idioma e nível vêm do servidor (template #language-item-template)
+function addSkill(name = '') {
+    const container = document.getElementById('skills-container');
+    const template = document.getElementById('skill-item-template');
+    const item = template.content.firstElementChild.cloneNode(true);
+    item.querySelector('[data-field="name"]').value = name;
+    container.appendChild(item);
+}
+
+function addLanguage() {
+    const container = document.getElementById('languages-container');
+    const template = document.getElementById('language-item-template');
+    container.appendChild(template.content.cloneNode(true));
+}
+
+function addPublication() {
+    const container = document.getElementById('publications-container');
+    const html = `
+    <div class="publication-item border border-gray-200 rounded-lg p-4">
+        <div class="grid grid-cols-1 md:grid-cols-2 gap-4">
+            <div>
+                <label class="block text-sm font-medium text-gray-700 mb-1">Título</label>
+                <input type="text" data-field="title" class="w-full p-2 border border-gray-300 rounded-lg">
+            </div>
+            <div>
+                <label class="block text-sm font-medium text-gray-700 mb-1">Tipo</label>
+                <select data-field="kind" class="w-full p-2 border border-gray-300 rounded-lg">
+                    <option value="ARTICLE">Artigo</option>
+                    <option value="TALK">Palestra</option>
+                    <option value="PAPER">Artigo científico</option>
+                    <option value="BOOK">Livro</option>
+                    <option value="PODCAST">Podcast</option>
+                </select>
+            </div>
+            <div>
+                <label class="block text-sm font-medium text-gray-700 mb-1">Veículo ou evento</label>
+                <input type="text" data-field="publisher" class="w-full p-2 border border-gray-300 rounded-lg" placeholder="Medium, GopherCon Brasil...">
+            </div>
+            <div>
+                <label class="block text-sm font-medium text-gray-700 mb-1">Data</label>
+                <input type="date" data-field="date" class="w-full p-2 border border-gray-300 rounded-lg">
+            </div>
+            <div class="md:col-span-2">
+                <label class="block text-sm font-medium text-gray-700 mb-1">URL</label>
+                <input type="url" data-field="url" class="w-full p-2 border border-gray-300 rounded-lg">
+            </div>
+            <div class="md:col-span-2">
+                <label class="block text-sm font-medium text-gray-700 mb-1">Descrição</label>
+                <textarea data-field="description" rows="2" class="w-full p-2 border border-gray-300 rounded-lg"></textarea>
+            </div>
+        </div>
+        <button type="button" onclick="removeItem(this, 'publication')" class="mt-2 text-red-600 hover:text-red-800 text-sm">🗑️ Remover</button>
+    </div>
+`;
+    container.insertAdjacentHTML('beforeend', html);
+}
+
+
+// ifMatch é a versão em que a edição foi baseada; por padrão a carregada no editor
+function sendFormData(data, ifMatch = currentVersionTag()){
+    const headers = {
+        'Content-Type': 'application/json',
+    };
+    if (ifMatch) {
+        headers['If-Match'] = ifMatch;
+    }
 
-function sendFormData(data){
      // Send via fetch with JSON
-    fetch('/app/profile', {
+    fetch('/app/profile' + currentProfileQuery(), {
         method: 'PUT',
-        headers: {
-            'Content-Type': 'application/json',
-        },
+        headers: headers,
         body: JSON.stringify(data)
     })
         .then(response => {
-            if (!response.ok) throw new Error('Falha na requisição');
+            if (response.status === 412) {
+                showVersionConflict(data, response.headers.get('ETag'));
+                throw new VersionConflictError();
+            }
+            rememberVersion(response.headers.get('ETag'));
+            if (response.status === 422) {
+                return response.json().then(body => {
+                    showValidationErrors(body.errors || []);
+                    throw new Error(body.error || 'Dados inválidos');
+                });
+            }
+            if (!response.ok) {
+                return response.text().then(message => {
+                    throw new Error(message.trim() || 'Falha na requisição');
+                });
+            }
             return response.text();
         })
         .then(html => {
@@ -227,19 +352,121 @@ function sendFormData(data){
             // Se você já estiver no modo edit, chamar toggleEditMode deve inverter.
             // Certifique-se que esta função faz o que você espera, ou force as classes aqui.
             toggleEditMode(); 
+
+            // 4. Atualiza o status de publicação (o rascunho agora difere do publicado)
+            refreshPublishStatus();
+            refreshCompleteness();
         })
         .catch(error => {
+            if (error instanceof VersionConflictError) return;
             console.error(error);
-            alert('Erro ao atualizar portfólio');
+            alert('Erro ao atualizar portfólio: ' + error.message);
         });
 }
 
+// Concorrência otimista: o servidor responde 412 se o portfólio foi salvo
+// depois que o editor foi carregado (outra aba ou dispositivo)
+class VersionConflictError extends Error {}
 
+let pendingOverwrite = null;
+
+function currentVersionTag() {
+    const input = document.querySelector('input[name="version"]');
+    return input && input.value ? `"v${input.value}"` : '';
+}
+
+function rememberVersion(etag) {
+    const match = /^"v(\d+)"$/.exec(etag || '');
+    const input = document.querySelector('input[name="version"]');
+    if (match && input) {
+        input.value = match[1];
+    }
+}
+
+function showVersionConflict(data, latestETag) {
+    pendingOverwrite = { data: data, etag: latestETag };
+    document.getElementById('version-conflict-dialog').showModal();
+}
+
+function closeVersionConflict() {
+    pendingOverwrite = null;
+    document.getElementById('version-conflict-dialog').close();
+}
+
+// Salva de novo baseado na versão atual do servidor. Se houver outra alteração
+// nesse meio tempo, o conflito é exibido novamente.
+function overwriteProfile() {
+    const pending = pendingOverwrite;
+    closeVersionConflict();
+    if (pending) {
+        sendFormData(pending.data, pending.etag || '');
+    }
+}
+
+
+
+// Portfólio sendo editado (vazio = principal)
+function currentProfileQuery() {
+    const input = document.querySelector('input[name="profile"]');
+    return input && input.value ? '?profile=' + encodeURIComponent(input.value) : '';
+}
+
+function refreshCompleteness() {
+    const completeness = document.getElementById('completeness');
+    if (!completeness) return;
+    htmx.ajax('GET', '/app/profile/completeness' + currentProfileQuery(), { target: '#completeness', swap: 'outerHTML' });
+}
+
+// Elementos do DOM correspondentes a cada item enviado (itens vazios são
+// descartados em prepareFormData, então o índice do JSON pode diferir do DOM)
+let submittedItems = { skills: [], experiences: [], projects: [], educations: [], certifications: [], languages: [], publications: [] };
+
+// Converte o JSON Pointer retornado pelo servidor no input correspondente.
+// Ex: "/social_links/github" -> [name="social_links.github"]
+//     "/experiences/0/endDate" -> .experience-item (enviado na posição 0) [data-field="endDate"]
+function findFieldByPath(path) {
+    const parts = path.split('/').slice(1);
+    const items = submittedItems[parts[0]];
+    if (items && parts.length >= 3) {
+        const item = items[parseInt(parts[1])];
+        return item ? item.querySelector(`[data-field="${parts[2]}"]`) : null;
+    }
+    return document.querySelector(`[name="${parts.join('.')}"]`);
+}
+
+function clearValidationErrors() {
+    document.querySelectorAll('.field-error').forEach(el => el.remove());
+    document.querySelectorAll('.border-red-500').forEach(el => el.classList.remove('border-red-500'));
+}
+
+function showValidationErrors(errors) {
+    clearValidationErrors();
+    let first = null;
+    errors.forEach(err => {
+        const field = findFieldByPath(err.path);
+        if (!field) return;
+        field.classList.add('border-red-500');
+        const message = document.createElement('p');
+        message.className = 'field-error text-xs text-red-600 mt-1';
+        message.textContent = err.message;
+        field.insertAdjacentElement('afterend', message);
+        if (!first) first = field;
+    });
+    if (first) first.scrollIntoView({ behavior: 'smooth', block: 'center' });
+}
+
+function refreshPublishStatus() {
+    const status = document.getElementById('publish-status');
+    if (!status) return;
+    htmx.ajax('GET', '/app/profile/publish' + currentProfileQuery(), { target: '#publish-status', swap: 'outerHTML' });
+}
 
 function prepareFormData(form) {
    console.log(form);
   const data = {
+        name: form.querySelector('[name="name"]').value.trim(),
         headline: form.querySelector('[name="headline"]').value,
+        slug: form.querySelector('[name="slug"]').value.trim().toLowerCase(),
         bio: form.querySelector('[name="bio"]').value,
         seniority: form.querySelector('[name="seniority"]').value,
         years_of_experience: parseInt(form.querySelector('[name="years_of_experience"]').value) || 0,
@@ -249,17 +476,43 @@ function prepareFormData(form) {
         contract_type: form.querySelector('[name="contract_type"]').value,
         location: form.querySelector('[name="location"]').value,
         remote_only: form.querySelector('[name="remote_only"]').checked,
-        skills: document.getElementById('skills-input').value.split(',').map(s => s.trim()).filter(s => s),
+        visibility: form.querySelector('[name="visibility"]').value,
+        field_privacy: {
+            salary: form.querySelector('[name="field_privacy.salary"]').value,
+            contract: form.querySelector('[name="field_privacy.contract"]').value
+        },
+        skills: [],
         social_links: {
             linkedin: form.querySelector('[name="social_links.linkedin"]').value,
             github: form.querySelector('[name="social_links.github"]').value,
             website: form.querySelector('[name="social_links.website"]').value
         },
+        default_locale: form.querySelector('[name="default_locale"]').value,
+        translations: collectTranslations(form),
         experiences: [],
         projects: [],
-        educations: []
+        educations: [],
+        certifications: [],
+        languages: [],
+        publications: []
     };
 
+    submittedItems = { skills: [], experiences: [], projects: [], educations: [], certifications: [], languages: [], publications: [] };
+
+    // Coletar Skills
+    form.querySelectorAll('.skill-item').forEach(item => {
+        const name = item.querySelector('[data-field="name"]').value.trim();
+        if (!name) return;
+        const lastUsed = item.querySelector('[data-field="lastUsed"]').value;
+        data.skills.push({
+            name: name,
+            level: item.querySelector('[data-field="level"]').value,
+            years: parseInt(item.querySelector('[data-field="years"]').value) || 0,
+            lastUsed: lastUsed ? new Date(lastUsed).toISOString() : null
+        });
+        submittedItems.skills.push(item);
+    });
+
     // Coletar Experiências
     form.querySelectorAll('.experience-item').forEach(item => {
         const exp = {
@@ -272,7 +525,10 @@ function prepareFormData(form) {
             techStack: item.querySelector('[data-field="techStack"]').value.split(',').map(s => s.trim()).filter(s => s)
         };
         // Pequena validação para não enviar objetos vazios
-        if (exp.company || exp.role) data.experiences.push(exp);
+        if (exp.company || exp.role) {
+            data.experiences.push(exp);
+            submittedItems.experiences.push(item);
+        }
     });
 
     // Coletar Projetos
@@ -288,9 +544,13 @@ function prepareFormData(form) {
             liveUrl: item.querySelector('[data-field="liveUrl"]').value,
             tags: item.querySelector('[data-field="tags"]').value.split(',').map(s => s.trim()).filter(s => s),
             provider: providerVal || null,
-            providerId: providerIdVal || null
+            providerId: providerIdVal || null,
+            media: collectProjectMedia(item)
         };
-        if (proj.name) data.projects.push(proj);
+        if (proj.name) {
+            data.projects.push(proj);
+            submittedItems.projects.push(item);
+        }
     });
 
     // Coletar Educação
@@ -302,15 +562,82 @@ function prepareFormData(form) {
             startDate: item.querySelector('[data-field="startDate"]').value ? new Date(item.querySelector('[data-field="startDate"]').value).toISOString() : null,
             endDate: item.querySelector('[data-field="endDate"]').value ? new Date(item.querySelector('[data-field="endDate"]').value).toISOString() : null
         };
-        if (edu.institution) data.educations.push(edu);
+        if (edu.institution) {
+            data.educations.push(edu);
+            submittedItems.educations.push(item);
+        }
+    });
+
+    // Coletar Certificações
+    form.querySelectorAll('.certification-item').forEach(item => {
+        const issueDate = item.querySelector('[data-field="issueDate"]').value;
+        const expiryDate = item.querySelector('[data-field="expiryDate"]').value;
+        const cert = {
+            name: item.querySelector('[data-field="name"]').value.trim(),
+            issuer: item.querySelector('[data-field="issuer"]').value.trim(),
+            credentialId: item.querySelector('[data-field="credentialId"]').value.trim(),
+            verificationUrl: item.querySelector('[data-field="verificationUrl"]').value.trim(),
+            issueDate: issueDate ? new Date(issueDate).toISOString() : null,
+            expiryDate: expiryDate ? new Date(expiryDate).toISOString() : null
+        };
+        if (cert.name) {
+            data.certifications.push(cert);
+            submittedItems.certifications.push(item);
+        }
+    });
+
+    // Coletar Idiomas
+    form.querySelectorAll('.language-item').forEach(item => {
+        data.languages.push({
+            language: item.querySelector('[data-field="language"]').value,
+            level: item.querySelector('[data-field="level"]').value
+        });
+        submittedItems.languages.push(item);
+    });
+
+    // Coletar Publicações
+    form.querySelectorAll('.publication-item').forEach(item => {
+        const date = item.querySelector('[data-field="date"]').value;
+        const pub = {
+            kind: item.querySelector('[data-field="kind"]').value,
+            title: item.querySelector('[data-field="title"]').value.trim(),
+            publisher: item.querySelector('[data-field="publisher"]').value.trim(),
+            date: date ? new Date(date).toISOString() : null,
+            url: item.querySelector('[data-field="url"]').value.trim(),
+            description: item.querySelector('[data-field="description"]').value
+        };
+        if (pub.title) {
+            data.publications.push(pub);
+            submittedItems.publications.push(item);
+        }
     });
     console.log(data)
    return data;
 }
 
+// Coleta as traduções por idioma. Descrições de experiências e projetos seguem
+// a ordem dos itens já salvos; idiomas totalmente em branco são omitidos.
+function collectTranslations(form) {
+    const translations = {};
+    form.querySelectorAll('.translation-item').forEach(item => {
+        const t = {
+            headline: item.querySelector('[data-field="headline"]').value.trim(),
+            bio: item.querySelector('[data-field="bio"]').value.trim(),
+            experiences: Array.from(item.querySelectorAll('[data-field="experience"]')).map(el => el.value.trim()),
+            projects: Array.from(item.querySelectorAll('[data-field="project"]')).map(el => el.value.trim())
+        };
+        const filled = t.headline || t.bio || t.experiences.some(s => s) || t.projects.some(s => s);
+        if (filled) {
+            translations[item.dataset.locale] = t;
+        }
+    });
+    return translations;
+}
+
 function submitProfileForm(event) {
     event.preventDefault();
     const form = event.target;
+    clearValidationErrors();
     const data = prepareFormData(form);
     sendFormData(data);
 }
@@ -416,14 +743,14 @@ async function handleLinkedinUploadEvent(event) {
 }
 
 function appendSkills(skills) {
-    const skillsInput = document.getElementById('skills-input');
-    const existingSkills = skillsInput.value.split(',').map(s => s.trim().toLowerCase());
+    const existingSkills = Array.from(document.querySelectorAll('.skill-item [data-field="name"]'))
+        .map(input => input.value.trim().toLowerCase());
     skills.forEach(skill => {
         if (!existingSkills.includes(skill.toLowerCase())) {
-            existingSkills.push(skill);
+            existingSkills.push(skill.toLowerCase());
+            addSkill(skill);
         }
     });
-    skillsInput.value = existingSkills.join(', ');
 }
 
 
@@ -647,4 +974,209 @@ function parseLinkedInProfile(rawText) {
     }
 
     return profile;
-}
\ No newline at end of file
+}
+
+// --- Importação do export do LinkedIn (ZIP de CSVs) ---
+
+const importFieldLabels = {
+    headline: 'Título Profissional',
+    bio: 'Bio',
+    socialLinks: 'Links Sociais',
+    skills: 'Habilidades',
+    experiences: 'Experiências',
+    projects: 'Projetos',
+    educations: 'Formação',
+    certifications: 'Certificações',
+    languages: 'Idiomas',
+    publications: 'Publicações'
+};
+
+async function sendLinkedInExport(dryRun) {
+    const input = document.getElementById('linkedin-zip-input');
+    if (!input.files.length) return null;
+
+    const body = new FormData();
+    body.append('file', input.files[0]);
+    const params = new URLSearchParams();
+    if (dryRun) params.set('dry_run', 'true');
+    const profileInput = document.querySelector('input[name="profile"]');
+    if (profileInput && profileInput.value) params.set('profile', profileInput.value);
+
+    const response = await fetch('/portfolio/me/import/linkedin?' + params.toString(), { method: 'POST', body });
+    if (!response.ok) {
+        const message = await response.text();
+        alert('Não foi possível importar o arquivo: ' + message);
+        return null;
+    }
+    return response.json();
+}
+
+// Mostra o que será mesclado antes de salvar
+async function previewLinkedInExport() {
+    const preview = document.getElementById('linkedin-import-preview');
+    const result = await sendLinkedInExport(true);
+    if (!result) return;
+
+    const items = result.changes.map(change => {
+        const label = importFieldLabels[change.field] || change.field;
+        if (Array.isArray(change.after)) {
+            const added = change.after.length - (change.before ? change.before.length : 0);
+            return `<li><strong>${label}:</strong> +${added} ${added === 1 ? 'item' : 'itens'}</li>`;
+        }
+        return `<li><strong>${label}:</strong> será preenchido</li>`;
+    });
+    const warnings = (result.warnings || []).map(w => `<li class="text-amber-700">${w}</li>`);
+
+    preview.innerHTML = `
+        <h4 class="font-bold text-gray-800 mb-2">Revisar importação do LinkedIn</h4>
+        ${items.length ? `<ul class="text-sm text-gray-700 list-disc ml-5 mb-2">${items.join('')}</ul>`
+                       : '<p class="text-sm text-gray-600 mb-2">Nada novo para importar: seu portfólio já contém esses dados.</p>'}
+        ${warnings.length ? `<ul class="text-xs list-disc ml-5 mb-2">${warnings.join('')}</ul>` : ''}
+        <p class="text-xs text-gray-500 mb-3">Dados existentes não são apagados; apenas itens novos são acrescentados ao rascunho.</p>
+        <div class="flex gap-2">
+            ${items.length ? '<button type="button" onclick="applyLinkedInExport()" class="bg-blue-600 text-white px-4 py-2 rounded-lg hover:bg-blue-700 text-sm">Mesclar ao portfólio</button>' : ''}
+            <button type="button" onclick="cancelLinkedInExport()" class="bg-gray-100 text-gray-700 px-4 py-2 rounded-lg hover:bg-gray-200 text-sm">Cancelar</button>
+        </div>`;
+    preview.classList.remove('hidden');
+}
+
+async function applyLinkedInExport() {
+    const result = await sendLinkedInExport(false);
+    if (result) {
+        window.location.reload();
+    }
+}
+
+function cancelLinkedInExport() {
+    document.getElementById('linkedin-zip-input').value = '';
+    const preview = document.getElementById('linkedin-import-preview');
+    preview.classList.add('hidden');
+    preview.innerHTML = '';
+}
+
+// Foto de perfil: enviada na hora (não depende de salvar o portfólio)
+async function uploadAvatar(event) {
+    const file = event.target.files[0];
+    if (!file) return;
+    if (file.size > 5 * 1024 * 1024) {
+        alert('A imagem deve ter no máximo 5 MB.');
+        event.target.value = '';
+        return;
+    }
+
+    const body = new FormData();
+    body.append('file', file);
+    const response = await fetch('/media/me/avatar', { method: 'POST', body });
+    if (!response.ok) {
+        const message = await response.text();
+        alert('Não foi possível enviar a foto: ' + message);
+        event.target.value = '';
+        return;
+    }
+    window.location.reload();
+}
+
+async function deleteAvatar() {
+    if (!confirm('Remover sua foto de perfil?')) return;
+    const response = await fetch('/media/me/avatar', { method: 'DELETE' });
+    if (!response.ok && response.status !== 404) {
+        alert('Não foi possível remover a foto.');
+        return;
+    }
+    window.location.reload();
+}
+
+// Galeria de projetos: o markup espelha o template "project_media_item"
+function mediaItemHtml(media) {
+    const kind = media.kind || 'image';
+    const preview = kind === 'image'
+        ? `<img src="${escapeHtml(media.thumbUrl || media.url)}" alt="" class="w-20 h-14 object-cover rounded">`
+        : `<div class="w-20 h-14 bg-gray-900 text-white rounded flex items-center justify-center text-xs">▶ Vídeo</div>`;
+    const urlInput = kind === 'video'
+        ? `<input type="url" data-media-field="url" value="${escapeHtml(media.url)}" placeholder="https://www.youtube.com/watch?v=..." class="w-full p-1.5 border border-gray-300 rounded text-sm">`
+        : '';
+    return `
+    <div class="media-item flex items-start gap-3 border border-gray-100 rounded-lg p-2"
+        data-kind="${escapeHtml(kind)}" data-url="${escapeHtml(media.url)}" data-thumb-url="${escapeHtml(media.thumbUrl)}"
+        data-width="${media.width || 0}" data-height="${media.height || 0}">
+        ${preview}
+        <div class="flex-1 grid grid-cols-1 gap-1">
+            ${urlInput}
+            <input type="text" data-media-field="caption" value="${escapeHtml(media.caption)}" maxlength="300" placeholder="Legenda" class="w-full p-1.5 border border-gray-300 rounded text-sm">
+            <input type="text" data-media-field="alt" value="${escapeHtml(media.alt)}" maxlength="300" placeholder="Texto alternativo (descreva a imagem)" class="w-full p-1.5 border border-gray-300 rounded text-sm">
+        </div>
+        <div class="flex flex-col gap-1 text-sm">
+            <button type="button" onclick="moveMediaItem(this, -1)" class="text-gray-500 hover:text-gray-800" title="Mover para cima">↑</button>
+            <button type="button" onclick="moveMediaItem(this, 1)" class="text-gray-500 hover:text-gray-800" title="Mover para baixo">↓</button>
+            <button type="button" onclick="this.closest('.media-item').remove()" class="text-red-600 hover:text-red-800" title="Remover">✕</button>
+        </div>
+    </div>`;
+}
+
+// A imagem é enviada na hora; o projeto só passa a referenciá-la ao salvar o portfólio
+async function uploadProjectImage(input) {
+    const file = input.files[0];
+    if (!file) return;
+    if (file.size > 5 * 1024 * 1024) {
+        alert('A imagem deve ter no máximo 5 MB.');
+        input.value = '';
+        return;
+    }
+
+    const list = input.closest('.project-item').querySelector('.media-list');
+    if (list.querySelectorAll('.media-item').length >= 12) {
+        alert('Cada projeto pode ter no máximo 12 mídias.');
+        input.value = '';
+        return;
+    }
+
+    const body = new FormData();
+    body.append('file', file);
+    const response = await fetch('/media/me/projects/images', { method: 'POST', body });
+    input.value = '';
+    if (!response.ok) {
+        const message = await response.text();
+        alert('Não foi possível enviar a imagem: ' + message);
+        return;
+    }
+    const media = await response.json();
+    list.insertAdjacentHTML('beforeend', mediaItemHtml(media));
+}
+
+function addProjectVideo(btn) {
+    const list = btn.closest('.project-item').querySelector('.media-list');
+    if (list.querySelectorAll('.media-item').length >= 12) {
+        alert('Cada projeto pode ter no máximo 12 mídias.');
+        return;
+    }
+    list.insertAdjacentHTML('beforeend', mediaItemHtml({ kind: 'video' }));
+    list.lastElementChild.querySelector('[data-media-field="url"]').focus();
+}
+
+function moveMediaItem(btn, direction) {
+    const item = btn.closest('.media-item');
+    if (direction < 0 && item.previousElementSibling) {
+        item.parentNode.insertBefore(item, item.previousElementSibling);
+    } else if (direction > 0 && item.nextElementSibling) {
+        item.parentNode.insertBefore(item.nextElementSibling, item);
+    }
+}
+
+function collectProjectMedia(projectItem) {
+    const media = [];
+    projectItem.querySelectorAll('.media-item').forEach(el => {
+        const urlInput = el.querySelector('[data-media-field="url"]');
+        const url = urlInput ? urlInput.value.trim() : el.dataset.url;
+        if (!url) return;
+        media.push({
+            kind: el.dataset.kind,
+            url,
+            thumbUrl: el.dataset.thumbUrl || undefined,
+            caption: el.querySelector('[data-media-field="caption"]').value.trim(),
+            alt: el.querySelector('[data-media-field="alt"]').value.trim(),
+            width: parseInt(el.dataset.width, 10) || 0,
+            height: parseInt(el.dataset.height, 10) || 0
+        });
+    });
+    return media;
+}